@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/HoodieCollin/dbexp/dialect"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "github.com/mattn/go-sqlite3"
+	_ "github.com/microsoft/go-mssqldb"
+)
+
+// runApply translates the schema at schemaPath into CREATE TABLE DDL for the
+// named dialect and executes it against dsn, wrapping the statement in a
+// transaction when the dialect supports transactional DDL.
+func runApply(dialectName, dsn, schemaPath string) error {
+	d, err := dialect.ForName(dialectName)
+	if err != nil {
+		return err
+	}
+
+	table, err := readTableSchema(schemaPath)
+	if err != nil {
+		return fmt.Errorf("reading schema: %w", err)
+	}
+
+	ddl, err := d.CreateTable(table)
+	if err != nil {
+		return fmt.Errorf("generating DDL: %w", err)
+	}
+
+	db, err := sql.Open(d.DriverName(), dsn)
+	if err != nil {
+		return fmt.Errorf("opening connection: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+
+	if !d.SupportsTransactionalDDL() {
+		if _, err := db.ExecContext(ctx, ddl); err != nil {
+			return fmt.Errorf("executing DDL: %w", err)
+		}
+		fmt.Printf("Applied %q to %s\n", table.Name, d.Name())
+		return nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, ddl); err != nil {
+		return fmt.Errorf("executing DDL: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	fmt.Printf("Applied %q to %s\n", table.Name, d.Name())
+	return nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// compatLevel classifies a schema.Change by how likely it is to break
+// existing data or consumers, from least to most disruptive.
+type compatLevel string
+
+const (
+	compatSafe     compatLevel = "safe"
+	compatRisky    compatLevel = "risky"
+	compatBreaking compatLevel = "breaking"
+)
+
+// compatResult pairs a schema.Change with its compatLevel for --json
+// output.
+type compatResult struct {
+	Change schema.Change `json:"change"`
+	Level  compatLevel   `json:"level"`
+}
+
+// classifyChange judges how disruptive c is to existing data and
+// consumers. Dropping a column or narrowing a type is always breaking;
+// adding a NOT NULL column without a default is breaking because existing
+// rows have nothing to populate it with. Widening a type or relaxing a
+// constraint is risky rather than safe, since a consumer that hard-codes
+// the old shape can still be surprised. Everything else purely additive
+// (a new nullable column, a new column with a default) is safe.
+func classifyChange(c schema.Change) compatLevel {
+	switch c.Kind {
+	case schema.ChangeAddField:
+		if c.NewField != nil && c.NewField.Required && c.NewField.Default == "" && !c.NewField.DefaultExpr {
+			return compatBreaking
+		}
+		return compatSafe
+
+	case schema.ChangeRemoveField:
+		return compatBreaking
+
+	case schema.ChangeTypeChanged:
+		if c.Destructive {
+			return compatBreaking
+		}
+		return compatRisky
+
+	case schema.ChangeConstraintChanged:
+		if c.OldField != nil && c.NewField != nil && !c.OldField.Required && c.NewField.Required {
+			if c.NewField.Default != "" || c.NewField.DefaultExpr {
+				return compatRisky
+			}
+			return compatBreaking
+		}
+		return compatRisky
+
+	case schema.ChangeViewChanged:
+		if c.Destructive {
+			return compatBreaking
+		}
+		return compatRisky
+
+	default:
+		return compatRisky
+	}
+}
+
+// runCompat diffs two versions of a schema file and classifies each
+// change as safe, risky, or breaking. It returns whether any breaking
+// change was found, so main can gate the exit code on --allow-breaking
+// without runCompat itself calling os.Exit.
+func runCompat() (bool, error) {
+	oldSchema, err := schema.LoadSchema(CLI.Compat.Old)
+	if err != nil {
+		return false, err
+	}
+	newSchema, err := schema.LoadSchema(CLI.Compat.New)
+	if err != nil {
+		return false, err
+	}
+
+	changes, err := schema.DiffSchemas(oldSchema, newSchema)
+	if err != nil {
+		return false, err
+	}
+
+	results := make([]compatResult, len(changes))
+	breaking := false
+	for i, c := range changes {
+		level := classifyChange(c)
+		results[i] = compatResult{Change: c, Level: level}
+		if level == compatBreaking {
+			breaking = true
+		}
+	}
+
+	if CLI.JSON {
+		return breaking, printJSON(results)
+	}
+
+	if len(results) == 0 {
+		fmt.Println("no differences")
+		return false, nil
+	}
+	for _, r := range results {
+		fmt.Printf("[%s] %s\n", r.Level, r.Change)
+	}
+	return breaking, nil
+}
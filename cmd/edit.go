@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/charmbracelet/huh"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// runEditTable loads the TableSchema TOML for name, walks the user through
+// keeping, removing, or modifying its existing fields, offers to add new
+// ones, then re-validates and rewrites the file. Field UUIDs are preserved
+// for every field that isn't dropped.
+func runEditTable(name string) error {
+	path := resolveTablePath(name)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	var table schema.TableSchema
+	if err := toml.Unmarshal(data, &table); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	for fieldName, field := range table.Fields {
+		keep := true
+		if err := huh.NewForm(huh.NewGroup(
+			huh.NewConfirm().Title(fmt.Sprintf("Keep field %q?", fieldName)).Value(&keep),
+		)).Run(); err != nil {
+			return err
+		}
+		if !keep {
+			delete(table.Fields, fieldName)
+			continue
+		}
+
+		modify := false
+		if err := huh.NewForm(huh.NewGroup(
+			huh.NewConfirm().Title(fmt.Sprintf("Modify field %q?", fieldName)).Value(&modify),
+		)).Run(); err != nil {
+			return err
+		}
+		if modify {
+			if err := editField(&field); err != nil {
+				return err
+			}
+			table.Fields[fieldName] = field
+		}
+	}
+
+	newFields, err := promptFields(table.Fields)
+	if err != nil {
+		return err
+	}
+	for fieldName, field := range newFields {
+		table.Fields[fieldName] = field
+	}
+
+	if err := schema.Validate(table, nil); err != nil {
+		return err
+	}
+
+	cfg, err := toml.Marshal(table)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, cfg, 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	fmt.Printf("Updated %s\n", path)
+	return nil
+}
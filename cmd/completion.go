@@ -0,0 +1,46 @@
+package main
+
+import (
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/alecthomas/kong"
+	"github.com/posener/complete"
+	"github.com/willabides/kongplete"
+)
+
+// tableNamePredictor lists the tables in schema.Dir, for completing a
+// table-name argument like "describe table <TAB>".
+var tableNamePredictor = complete.PredictFunc(func(complete.Args) []string {
+	schemas, err := schema.AllSchemas()
+	if err != nil {
+		return nil
+	}
+	names := make([]string, len(schemas))
+	for i, s := range schemas {
+		names[i] = s.Name
+	}
+	return names
+})
+
+// fieldNamePredictor lists the fields of the table named by the argument
+// just before the one being completed, for completing a field-name
+// argument like "remove field <table> <TAB>".
+var fieldNamePredictor = complete.PredictFunc(func(a complete.Args) []string {
+	if len(a.Completed) == 0 {
+		return nil
+	}
+	table := a.Completed[len(a.Completed)-1]
+	s, err := schema.LoadSchemaByName(table)
+	if err != nil {
+		return nil
+	}
+	return s.FieldOrder()
+})
+
+// registerCompletion wires kongplete into parser, so COMP_LINE-driven
+// completion requests are answered before Parse ever sees real arguments.
+func registerCompletion(parser *kong.Kong) {
+	kongplete.Complete(parser,
+		kongplete.WithPredictor("tables", tableNamePredictor),
+		kongplete.WithPredictor("fields", fieldNamePredictor),
+	)
+}
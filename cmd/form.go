@@ -0,0 +1,339 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/charmbracelet/huh"
+	"github.com/google/uuid"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// dataTypeOptions lists every schema.DataType in the order they should
+// appear in the interactive picker.
+var dataTypeOptions = []schema.DataType{
+	schema.DataTypeInt8,
+	schema.DataTypeInt16,
+	schema.DataTypeInt32,
+	schema.DataTypeInt64,
+	schema.DataTypeFloat32,
+	schema.DataTypeFloat64,
+	schema.DataTypeDecimal,
+	schema.DataTypeBool,
+	schema.DataTypeText,
+	schema.DataTypeVarchar,
+	schema.DataTypeBytes,
+	schema.DataTypeJSON,
+	schema.DataTypeEnum,
+	schema.DataTypeArray,
+	schema.DataTypeUUID,
+	schema.DataTypeTimestamp,
+}
+
+// promptFields interactively builds the custom fields for a table, looping
+// until the user declines to add another. existing seeds the starting field
+// set (e.g. a table's current fields when editing) so the confirm defaults
+// to "no" instead of forcing the user through the field wizard at least
+// once when fields already exist.
+func promptFields(existing map[string]schema.TableField) (map[string]schema.TableField, error) {
+	fields := map[string]schema.TableField{}
+	for name, f := range existing {
+		fields[name] = f
+	}
+
+	for {
+		addAnother := len(fields) == 0
+		confirmTitle := "Add a field?"
+		if len(fields) > 0 {
+			confirmTitle = "Add another field?"
+		}
+
+		if err := huh.NewForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title(confirmTitle).
+					Value(&addAnother),
+			),
+		).Run(); err != nil {
+			return nil, err
+		}
+
+		if !addAnother {
+			break
+		}
+
+		name, field, err := promptField()
+		if err != nil {
+			return nil, err
+		}
+		fields[name] = field
+	}
+
+	return fields, nil
+}
+
+// promptField prompts for a single field's name, type, type-specific
+// parameters, foreign key target, and flags.
+func promptField() (string, schema.TableField, error) {
+	var name string
+	var dataType schema.DataType
+
+	if err := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Field Name").
+				Validate(func(s string) error {
+					if s == "" {
+						return fmt.Errorf("field name cannot be empty")
+					}
+					return nil
+				}).
+				Value(&name),
+			huh.NewSelect[schema.DataType]().
+				Title("Field Type").
+				Options(dataTypeSelectOptions()...).
+				Value(&dataType),
+		),
+	).Run(); err != nil {
+		return "", schema.TableField{}, err
+	}
+
+	field := schema.TableField{Id: uuid.New(), Type: dataType}
+
+	if err := promptTypeParams(&field); err != nil {
+		return "", schema.TableField{}, err
+	}
+
+	if err := promptForeignKey(&field); err != nil {
+		return "", schema.TableField{}, err
+	}
+
+	if err := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().Title("Unique?").Value(&field.Unique),
+			huh.NewConfirm().Title("Required?").Value(&field.Required),
+			huh.NewConfirm().Title("Automatic (set by the database)?").Value(&field.Automatic),
+			huh.NewConfirm().Title("Primary key?").Value(&field.PrimaryKey),
+		),
+	).Run(); err != nil {
+		return "", schema.TableField{}, err
+	}
+
+	return name, field, nil
+}
+
+// editField lets the user change an existing field's type, foreign key, and
+// flags in place, preserving its Id. The user is first asked whether to
+// change the type; only on "yes" are the type and its parameters prompted
+// for again.
+func editField(field *schema.TableField) error {
+	changeType := false
+	if err := huh.NewForm(huh.NewGroup(
+		huh.NewConfirm().Title("Change field type?").Value(&changeType),
+	)).Run(); err != nil {
+		return err
+	}
+
+	if changeType {
+		if err := huh.NewForm(huh.NewGroup(
+			huh.NewSelect[schema.DataType]().
+				Title("Field Type").
+				Options(dataTypeSelectOptions()...).
+				Value(&field.Type),
+		)).Run(); err != nil {
+			return err
+		}
+		if err := promptTypeParams(field); err != nil {
+			return err
+		}
+	}
+
+	if err := promptForeignKey(field); err != nil {
+		return err
+	}
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().Title("Unique?").Value(&field.Unique),
+			huh.NewConfirm().Title("Required?").Value(&field.Required),
+			huh.NewConfirm().Title("Automatic (set by the database)?").Value(&field.Automatic),
+			huh.NewConfirm().Title("Primary key?").Value(&field.PrimaryKey),
+		),
+	).Run()
+}
+
+func dataTypeSelectOptions() []huh.Option[schema.DataType] {
+	opts := make([]huh.Option[schema.DataType], len(dataTypeOptions))
+	for i, dt := range dataTypeOptions {
+		opts[i] = huh.NewOption(string(dt), dt)
+	}
+	return opts
+}
+
+// promptTypeParams asks for whatever extra parameters field.Type requires
+// (varchar length, decimal precision/scale, enum values, array element
+// type).
+func promptTypeParams(field *schema.TableField) error {
+	switch field.Type {
+	case schema.DataTypeVarchar:
+		var length string
+		if err := huh.NewForm(huh.NewGroup(
+			huh.NewInput().Title("Length").Value(&length),
+		)).Run(); err != nil {
+			return err
+		}
+		n, err := strconv.Atoi(length)
+		if err != nil {
+			return fmt.Errorf("length must be a number: %w", err)
+		}
+		field.Length = n
+
+	case schema.DataTypeDecimal:
+		var prec, scale string
+		if err := huh.NewForm(huh.NewGroup(
+			huh.NewInput().Title("Precision").Value(&prec),
+			huh.NewInput().Title("Scale").Value(&scale),
+		)).Run(); err != nil {
+			return err
+		}
+		p, err := strconv.Atoi(prec)
+		if err != nil {
+			return fmt.Errorf("precision must be a number: %w", err)
+		}
+		s, err := strconv.Atoi(scale)
+		if err != nil {
+			return fmt.Errorf("scale must be a number: %w", err)
+		}
+		field.Prec, field.Scale = p, s
+
+	case schema.DataTypeEnum:
+		var values string
+		if err := huh.NewForm(huh.NewGroup(
+			huh.NewInput().
+				Title("Enum Values").
+				Placeholder("comma,separated,values").
+				Value(&values),
+		)).Run(); err != nil {
+			return err
+		}
+		field.Enum = splitEnumValues(values)
+
+	case schema.DataTypeArray:
+		var elem schema.DataType
+		if err := huh.NewForm(huh.NewGroup(
+			huh.NewSelect[schema.DataType]().
+				Title("Element Type").
+				Options(dataTypeSelectOptions()...).
+				Value(&elem),
+		)).Run(); err != nil {
+			return err
+		}
+		field.Element = &elem
+	}
+
+	return nil
+}
+
+// promptForeignKey optionally attaches a ForeignKey to field, offering a
+// picker built from the TableSchema TOML files found in the project's tables
+// directory (or the current directory outside a project).
+func promptForeignKey(field *schema.TableField) error {
+	targets, err := discoverTableFiles(tablesDir())
+	if err != nil || len(targets) == 0 {
+		return nil
+	}
+
+	wantsFK := false
+	if err := huh.NewForm(huh.NewGroup(
+		huh.NewConfirm().Title("Reference another table?").Value(&wantsFK),
+	)).Run(); err != nil {
+		return err
+	}
+	if !wantsFK {
+		return nil
+	}
+
+	byName := make(map[string]schema.TableSchema, len(targets))
+	options := make([]huh.Option[string], len(targets))
+	for i, t := range targets {
+		byName[t.Name] = t
+		options[i] = huh.NewOption(t.Name, t.Name)
+	}
+
+	var tableName string
+	if err := huh.NewForm(huh.NewGroup(
+		huh.NewSelect[string]().
+			Title("Referenced Table").
+			Options(options...).
+			Value(&tableName),
+	)).Run(); err != nil {
+		return err
+	}
+	table := byName[tableName]
+
+	fieldNames := make([]string, 0, len(table.Fields))
+	for name := range table.Fields {
+		fieldNames = append(fieldNames, name)
+	}
+	var targetField string
+	fieldOptions := make([]huh.Option[string], len(fieldNames))
+	for i, name := range fieldNames {
+		fieldOptions[i] = huh.NewOption(name, name)
+	}
+	if err := huh.NewForm(huh.NewGroup(
+		huh.NewSelect[string]().
+			Title("Referenced Field").
+			Options(fieldOptions...).
+			Value(&targetField),
+	)).Run(); err != nil {
+		return err
+	}
+
+	field.ForeignKey = &schema.ForeignKey{
+		Table:   table.Name,
+		TableId: table.Id,
+		Field:   targetField,
+		FieldId: table.Fields[targetField].Id,
+	}
+	return nil
+}
+
+// discoverTableFiles reads every *.toml file directly inside dir and parses
+// it as a TableSchema, skipping anything that doesn't look like one.
+func discoverTableFiles(dir string) ([]schema.TableSchema, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []schema.TableSchema
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".toml" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue
+		}
+		var t schema.TableSchema
+		if err := toml.Unmarshal(data, &t); err != nil || t.Name == "" {
+			continue
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+func splitEnumValues(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			out = append(out, trimmed)
+		}
+	}
+	return out
+}
@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/charmbracelet/huh"
+)
+
+// runConvertId rewrites table's "id" field between a serial integer and a
+// uuid, or bumps an existing uuid id's version with --to uuid
+// --uuid-version: DataType, AutoIncrement, Automatic, UUIDVersion, and any
+// Default/DefaultExpr are all rewritten to match the target strategy, since
+// the new id always gets its value from the target's own built-in
+// generator rather than whatever default the old strategy used. A foreign
+// key elsewhere (or a self-referencing one on table itself) that
+// references table.id is rejected unless --update-refs, since it has to
+// carry the same DataType for schema.ValidateReferences to accept it.
+//
+// This only rewrites schema files, and warns before doing anything: it has
+// no idea whether a real database backs the table, and re-typing a live
+// column (plus backfilling values under the new strategy) is on the
+// caller.
+func runConvertId() error {
+	table := CLI.Convert.Id.Table
+	to := CLI.Convert.Id.To
+
+	s, err := schema.LoadSchemaFile(table)
+	if err != nil {
+		return err
+	}
+	old, ok := s.Fields["id"]
+	if !ok {
+		return fmt.Errorf("table %q has no \"id\" field to convert", table)
+	}
+
+	newId := old
+	newId.Default = ""
+	newId.DefaultExpr = false
+	switch to {
+	case "serial":
+		if old.AutoIncrement && (old.DataType == schema.DataTypeInt32 || old.DataType == schema.DataTypeInt64) {
+			return fmt.Errorf("table %q: id is already a serial integer", table)
+		}
+		newId.DataType = schema.DataTypeInt64
+		newId.AutoIncrement = true
+		newId.Automatic = true
+		newId.Required = true
+		newId.UUIDVersion = 0
+	case "uuid":
+		if old.DataType == schema.DataTypeUUID && CLI.Convert.Id.UUIDVersion == "" {
+			return fmt.Errorf("table %q: id is already a uuid, pass --uuid-version to change its version", table)
+		}
+		uuidVersion := defaultUUIDVersion
+		if CLI.Convert.Id.UUIDVersion != "" {
+			v, err := ParseUUIDVersion(CLI.Convert.Id.UUIDVersion)
+			if err != nil {
+				return err
+			}
+			uuidVersion = v
+		}
+		if old.DataType == schema.DataTypeUUID && old.UUIDVersion == uuidVersion {
+			return fmt.Errorf("table %q: id is already a uuid with version %d", table, uuidVersion)
+		}
+		newId.DataType = schema.DataTypeUUID
+		newId.AutoIncrement = false
+		newId.Automatic = true
+		newId.Required = true
+		newId.UUIDVersion = uuidVersion
+	default:
+		return fmt.Errorf("convert id: --to must be serial or uuid, got %q", to)
+	}
+
+	var selfRefs []string
+	for fieldName, f := range s.Fields {
+		if fieldName != "id" && f.References != nil && f.References.Table == table && f.References.Field == "id" && f.DataType != newId.DataType {
+			selfRefs = append(selfRefs, fieldName)
+		}
+	}
+	sort.Strings(selfRefs)
+
+	dependents, err := schema.FindDependents(table)
+	if err != nil {
+		return err
+	}
+	affected := map[string]schema.TableSchema{}
+	affectedFields := map[string][]string{}
+	for _, dep := range dependents {
+		depSchema, err := schema.LoadSchemaFile(dep)
+		if err != nil {
+			return err
+		}
+		var touched []string
+		for fieldName, f := range depSchema.Fields {
+			if f.References != nil && f.References.Table == table && f.References.Field == "id" && f.DataType != newId.DataType {
+				f.DataType = newId.DataType
+				depSchema.Fields[fieldName] = f
+				touched = append(touched, fieldName)
+			}
+		}
+		if len(touched) > 0 {
+			sort.Strings(touched)
+			affected[dep] = depSchema
+			affectedFields[dep] = touched
+		}
+	}
+
+	if (len(selfRefs) > 0 || len(affected) > 0) && !CLI.Convert.Id.UpdateRefs {
+		names := make([]string, 0, len(affected)+1)
+		if len(selfRefs) > 0 {
+			names = append(names, table)
+		}
+		for name := range affected {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("table %q: id is referenced by %v, pass --update-refs to update them", table, names)
+	}
+
+	oldSchema := s
+	oldSchema.Fields = make(map[string]schema.TableField, len(s.Fields))
+	for k, v := range s.Fields {
+		oldSchema.Fields[k] = v
+	}
+	oldSchema.Order = append([]string{}, s.FieldOrder()...)
+
+	s.Fields["id"] = newId
+	for _, fieldName := range selfRefs {
+		f := s.Fields[fieldName]
+		f.DataType = newId.DataType
+		s.Fields[fieldName] = f
+	}
+	if s.Version == 0 {
+		s.Version = 1
+	}
+	s.Version++
+
+	if err := schema.Validate(s); err != nil {
+		return err
+	}
+	for name, depSchema := range affected {
+		if err := schema.Validate(depSchema); err != nil {
+			return fmt.Errorf("table %q: %w", name, err)
+		}
+	}
+
+	if CLI.Convert.Id.DryRun {
+		changes, err := schema.DiffSchemas(oldSchema, s)
+		if err != nil {
+			return err
+		}
+		for _, c := range changes {
+			fmt.Println(c.String())
+		}
+		names := make([]string, 0, len(affectedFields))
+		for name := range affectedFields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("would also update %s.%s to %s\n", name, strings.Join(affectedFields[name], ", "), newId.DataType)
+		}
+		return nil
+	}
+
+	fmt.Printf("Warning: this is a destructive, data-affecting change against a real database. Converting %q's id from %s to %s also rewrites its generated values; existing rows aren't migrated by this tool.\n", table, old.DataType, newId.DataType)
+	if !CLI.Convert.Id.Yes {
+		confirmed := false
+		if err := huh.NewConfirm().
+			Title(fmt.Sprintf("Convert %q's id field from %s to %s?", table, old.DataType, newId.DataType)).
+			Description("Apply the equivalent migration against any live database yourself.").
+			Value(&confirmed).Run(); err != nil {
+			return err
+		}
+		if !confirmed {
+			return fmt.Errorf("aborted")
+		}
+	}
+
+	if err := schema.SaveSchemaFile(s); err != nil {
+		return err
+	}
+	for _, depSchema := range affected {
+		if err := schema.SaveSchemaFile(depSchema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
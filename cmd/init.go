@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/HoodieCollin/dbexp/project"
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/charmbracelet/huh"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// runInitTable builds a new TableSchema, prompting for the table name (if
+// not already given) and for any custom fields beyond the standard
+// id/created_at/updated_at set, then validates it. Inside a dbexp project it
+// is written to the tables directory and added to the manifest's table
+// index; outside one it's printed to stdout as before.
+func runInitTable(name string) error {
+	if name == "" {
+		if err := huh.NewForm(
+			huh.NewGroup(
+				huh.NewInput().
+					Title("Table Name").
+					Placeholder("Enter the name of the table.").
+					Validate(func(s string) error {
+						if s == "" {
+							return fmt.Errorf("table name cannot be empty")
+						}
+						return nil
+					}).
+					Value(&name),
+			),
+		).Run(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("Table Name:", name)
+
+	table := schema.New(name)
+
+	customFields, err := promptFields(nil)
+	if err != nil {
+		return err
+	}
+	for fieldName, field := range customFields {
+		table.Fields[fieldName] = field
+	}
+
+	if err := schema.Validate(table, nil); err != nil {
+		return err
+	}
+
+	cfg, err := toml.Marshal(table)
+	if err != nil {
+		return err
+	}
+
+	m, dir, err := project.Find(".")
+	if err != nil {
+		fmt.Println(string(cfg))
+		return nil
+	}
+
+	path := filepath.Join(m.TablesPath(dir), name+".toml")
+	if err := os.WriteFile(path, cfg, 0o644); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+
+	m.AddTable(name)
+	if err := project.Save(dir, m); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %s\n", path)
+	return nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// runMigrateGenerate diffs the previous version of a table's schema
+// against its current file and writes the resulting up/down Postgres
+// migration to a pair of timestamped files under Dir.
+func runMigrateGenerate() error {
+	table := CLI.Migrate.Generate.Table
+	oldSchema, err := schema.LoadSchema(CLI.Migrate.Generate.Old)
+	if err != nil {
+		return err
+	}
+	newSchema, err := schema.LoadSchema(schema.Path(table))
+	if err != nil {
+		return err
+	}
+
+	if CLI.Migrate.Generate.DryRun {
+		changes, err := schema.DiffSchemas(oldSchema, newSchema)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 {
+			fmt.Println("no differences, nothing to generate")
+			return nil
+		}
+		up, down, err := changes.ToPostgresMigration()
+		if err != nil {
+			return err
+		}
+		stamp := time.Now().Format("20060102150405")
+		fmt.Println("-- " + filepath.Join(CLI.Migrate.Generate.Dir, fmt.Sprintf("%s_%s.up.sql", stamp, table)))
+		fmt.Println(up)
+		fmt.Println("-- " + filepath.Join(CLI.Migrate.Generate.Dir, fmt.Sprintf("%s_%s.down.sql", stamp, table)))
+		fmt.Println(down)
+		return nil
+	}
+
+	wrote, err := writeMigration(CLI.Migrate.Generate.Dir, table, oldSchema, newSchema)
+	if err != nil {
+		return err
+	}
+	if !wrote {
+		fmt.Println("no differences, nothing to generate")
+	}
+	return nil
+}
+
+// writeMigration diffs old against newSchema and, if they differ, writes a
+// timestamped up/down Postgres migration pair to dir and records
+// newSchema's fingerprint as migrated. It reports whether a migration was
+// written, so a caller with nothing to do (no structural change) can
+// say so without treating it as an error.
+func writeMigration(dir, table string, old, newSchema schema.TableSchema) (bool, error) {
+	changes, err := schema.DiffSchemas(old, newSchema)
+	if err != nil {
+		return false, err
+	}
+	if len(changes) == 0 {
+		return false, nil
+	}
+
+	up, down, err := changes.ToPostgresMigration()
+	if err != nil {
+		return false, err
+	}
+
+	stamp := time.Now().Format("20060102150405")
+	upPath := filepath.Join(dir, fmt.Sprintf("%s_%s.up.sql", stamp, table))
+	downPath := filepath.Join(dir, fmt.Sprintf("%s_%s.down.sql", stamp, table))
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(upPath, []byte(up+"\n"), 0o644); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(downPath, []byte(down+"\n"), 0o644); err != nil {
+		return false, err
+	}
+	if err := recordMigrationState(table, newSchema.Fingerprint()); err != nil {
+		return false, err
+	}
+	statusf("Wrote %s", upPath)
+	statusf("Wrote %s", downPath)
+	return true, nil
+}
+
+func runDiff() error {
+	oldSchema, err := schema.LoadSchema(CLI.Diff.Files.Old)
+	if err != nil {
+		return err
+	}
+	newSchema, err := schema.LoadSchema(CLI.Diff.Files.New)
+	if err != nil {
+		return err
+	}
+
+	changes, err := schema.DiffSchemas(oldSchema, newSchema)
+	if err != nil {
+		return err
+	}
+	if CLI.JSON {
+		if changes == nil {
+			changes = schema.ChangeSet{}
+		}
+		return printJSON(changes)
+	}
+	if len(changes) == 0 {
+		fmt.Println("no differences")
+		return nil
+	}
+	for _, c := range changes {
+		fmt.Println(c)
+	}
+	return nil
+}
+
+// runDiffPostgres compares the on-disk schema for a table against its
+// live shape in a Postgres database, reporting drift the same way runDiff
+// reports a file-to-file diff. It returns true when drift was found, so
+// main can exit non-zero without runDiffPostgres itself calling os.Exit.
+func runDiffPostgres() (bool, error) {
+	table := CLI.Diff.Postgres.Table
+
+	diskSchema, err := schema.LoadSchema(schema.Path(table))
+	if err != nil {
+		return false, err
+	}
+	liveSchema, err := schema.ImportPostgresTable(CLI.Diff.Postgres.Dsn, table, func(msg string) {
+		warnf("%s", msg)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	changes, err := schema.DiffSchemas(diskSchema, liveSchema)
+	if err != nil {
+		return false, err
+	}
+	if CLI.JSON {
+		if changes == nil {
+			changes = schema.ChangeSet{}
+		}
+		return len(changes) > 0, printJSON(changes)
+	}
+	if len(changes) == 0 {
+		fmt.Println("no drift: database matches", schema.Path(table))
+		return false, nil
+	}
+	fmt.Printf("drift between %s (schema file) and the live database:\n", schema.Path(table))
+	for _, c := range changes {
+		fmt.Println(c)
+	}
+	return true, nil
+}
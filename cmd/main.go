@@ -4,103 +4,131 @@ import (
 	"fmt"
 
 	"github.com/alecthomas/kong"
-	"github.com/charmbracelet/huh"
-	"github.com/google/uuid"
-	"github.com/pelletier/go-toml/v2"
 )
 
 var CLI struct {
 	Init struct {
+		Project struct {
+			Name    string `arg:"" help:"Name of the project."`
+			Dialect string `help:"Default SQL dialect for this project." enum:"postgres,mysql,sqlite,sqlserver" default:"postgres"`
+		} `cmd:"" help:"Initialize a new project workspace."`
+
 		Table struct {
 			Name string `help:"Name of the table."`
 		} `cmd:"" help:"Initialize a new table."`
 	} `cmd:"" help:"Initialize a new project or resource."`
+
+	Edit struct {
+		Table struct {
+			Name string `arg:"" help:"Name of the table to edit."`
+		} `cmd:"" help:"Edit an existing table's fields."`
+	} `cmd:"" help:"Edit an existing project resource."`
+
+	Migrate struct {
+		New struct {
+			Dir  string `help:"Migrations directory." default:"migrations"`
+			From string `help:"Path to the previous TableSchema TOML snapshot." required:""`
+			To   string `help:"Path to the new TableSchema TOML snapshot." required:""`
+		} `cmd:"" help:"Create a new migration from a schema diff."`
+
+		Up struct {
+			Dir    string `help:"Migrations directory." default:"migrations"`
+			To     string `help:"Target version to migrate up to." name:"to"`
+			DryRun bool   `help:"Print the plan without applying it."`
+		} `cmd:"" help:"Apply pending migrations."`
+
+		Down struct {
+			Dir    string `help:"Migrations directory." default:"migrations"`
+			To     string `help:"Target version to migrate down to." name:"to"`
+			DryRun bool   `help:"Print the plan without applying it."`
+		} `cmd:"" help:"Revert applied migrations."`
+
+		Status struct {
+			Dir string `help:"Migrations directory." default:"migrations"`
+		} `cmd:"" help:"Show which migrations have been applied."`
+
+		Redo struct {
+			Dir    string `help:"Migrations directory." default:"migrations"`
+			DryRun bool   `help:"Print the plan without applying it."`
+		} `cmd:"" help:"Revert and reapply the most recent migration."`
+	} `cmd:"" help:"Manage schema migrations."`
+
+	Apply struct {
+		Dialect string `help:"Target SQL dialect." enum:"postgres,mysql,sqlite,sqlserver" required:""`
+		DSN     string `help:"Data source name for the target database." required:""`
+		Schema  string `arg:"" help:"Path to the TableSchema TOML file to deploy."`
+	} `cmd:"" help:"Deploy a schema as DDL against a real database."`
+
+	Seed struct {
+		Table   string `help:"Name of the table to seed." required:""`
+		Count   int    `help:"Number of rows to generate." default:"10"`
+		Dialect string `help:"SQL dialect, used for --out sql and --dsn inserts." enum:"postgres,mysql,sqlite,sqlserver" default:"postgres"`
+		DSN     string `help:"Data source name to insert rows directly into, instead of printing them."`
+		Out     string `help:"Output format when --dsn isn't set." enum:"sql,csv,jsonl" default:"sql"`
+	} `cmd:"" help:"Generate fake data for a table from its schema."`
+
+	Validate struct{} `cmd:"" help:"Validate every table in the current project."`
 }
 
 func main() {
 	ctx := kong.Parse(&CLI)
 	switch ctx.Command() {
+	case "init project <name>":
+		if err := runInitProject(CLI.Init.Project.Name, CLI.Init.Project.Dialect); err != nil {
+			fmt.Println("Error:", err)
+		}
+
 	case "init table":
-		name := CLI.Init.Table.Name
-
-		if name == "" {
-			form := huh.NewForm(
-				huh.NewGroup(
-					huh.NewInput().
-						Title("Table Name").
-						Placeholder("Enter the name of the table.").
-						Validate(func(s string) error {
-							if s == "" {
-								return fmt.Errorf("table name cannot be empty")
-							}
-							return nil
-						}).
-						Value(&name),
-				),
-			)
-
-			if err := form.Run(); err != nil {
-				fmt.Println("Error:", err)
-				return
-			}
+		if err := runInitTable(CLI.Init.Table.Name); err != nil {
+			fmt.Println("Error:", err)
 		}
 
-		fmt.Println("Table Name:", name)
-		cfg, err := toml.Marshal(TableSchema{
-			Id:   uuid.New(),
-			Name: name,
-			Fields: map[string]TableField{
-				"id": {
-					Id:        uuid.New(),
-					DataType:  DataTypeUUID,
-					Unique:    true,
-					Required:  true,
-					Automatic: true,
-				},
-				"created_at": {
-					Id:        uuid.New(),
-					DataType:  DataTypeTimestamp,
-					Required:  true,
-					Automatic: true,
-				},
-				"updated_at": {
-					Id:        uuid.New(),
-					DataType:  DataTypeTimestamp,
-					Required:  true,
-					Automatic: true,
-				},
-			},
-		})
-
-		if err != nil {
+	case "edit table <name>":
+		if err := runEditTable(CLI.Edit.Table.Name); err != nil {
 			fmt.Println("Error:", err)
-			return
 		}
 
-		fmt.Println(string(cfg))
+	case "migrate new":
+		if err := runMigrateNew(CLI.Migrate.New.Dir, CLI.Migrate.New.From, CLI.Migrate.New.To); err != nil {
+			fmt.Println("Error:", err)
+		}
 
-	default:
-		panic(ctx.Command())
-	}
-}
+	case "migrate up":
+		if err := runMigrateUp(CLI.Migrate.Up.Dir, CLI.Migrate.Up.To, CLI.Migrate.Up.DryRun); err != nil {
+			fmt.Println("Error:", err)
+		}
 
-type DataType string
+	case "migrate down":
+		if err := runMigrateDown(CLI.Migrate.Down.Dir, CLI.Migrate.Down.To, CLI.Migrate.Down.DryRun); err != nil {
+			fmt.Println("Error:", err)
+		}
 
-const (
-	DataTypeUUID      DataType = "uuid"
-	DataTypeTimestamp DataType = "timestamp"
-)
+	case "migrate status":
+		if err := runMigrateStatus(CLI.Migrate.Status.Dir); err != nil {
+			fmt.Println("Error:", err)
+		}
 
-type TableSchema struct {
-	Id     uuid.UUID             `toml:"id"`
-	Name   string                `toml:"name"`
-	Fields map[string]TableField `toml:"fields"`
-}
+	case "migrate redo":
+		if err := runMigrateRedo(CLI.Migrate.Redo.Dir, CLI.Migrate.Redo.DryRun); err != nil {
+			fmt.Println("Error:", err)
+		}
 
-type TableField struct {
-	Id        uuid.UUID `toml:"id"`
-	DataType  DataType  `toml:"type"`
-	Unique    bool      `toml:"unique"`
-	Required  bool      `toml:"required"`
-	Automatic bool      `toml:"automatic"`
+	case "apply <schema>":
+		if err := runApply(CLI.Apply.Dialect, CLI.Apply.DSN, CLI.Apply.Schema); err != nil {
+			fmt.Println("Error:", err)
+		}
+
+	case "seed":
+		if err := runSeed(CLI.Seed.Table, CLI.Seed.Count, CLI.Seed.Dialect, CLI.Seed.DSN, CLI.Seed.Out); err != nil {
+			fmt.Println("Error:", err)
+		}
+
+	case "validate":
+		if err := runValidate(); err != nil {
+			fmt.Println("Error:", err)
+		}
+
+	default:
+		panic(ctx.Command())
+	}
 }
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/charmbracelet/huh"
+	"github.com/google/uuid"
+)
+
+// runEditTable loads an existing table and lets the caller add, remove,
+// reorder, or modify its fields across repeated menu selections, all
+// against an in-memory copy. Nothing is written until "Save", and Validate
+// runs first: a failure prints the error and returns to the menu with
+// every edit so far intact, rather than discarding them.
+func runEditTable() error {
+	name := CLI.Edit.Table.Name
+
+	s, err := schema.LoadSchemaFile(name)
+	if err != nil {
+		return err
+	}
+
+	for {
+		printFieldList(s)
+
+		action := ""
+		if err := huh.NewSelect[string]().
+			Title("Edit table " + s.Name).
+			Options(huh.NewOptions(
+				"Add field",
+				"Modify field",
+				"Remove field",
+				"Reorder fields",
+				"Save",
+				"Cancel",
+			)...).
+			Value(&action).Run(); err != nil {
+			return err
+		}
+
+		switch action {
+		case "Add field":
+			if err := editAddField(&s); err != nil {
+				fmt.Println("Error:", err)
+			}
+		case "Modify field":
+			if err := editModifyField(&s); err != nil {
+				fmt.Println("Error:", err)
+			}
+		case "Remove field":
+			if err := editRemoveField(&s); err != nil {
+				fmt.Println("Error:", err)
+			}
+		case "Reorder fields":
+			if err := editReorderFields(&s); err != nil {
+				fmt.Println("Error:", err)
+			}
+		case "Save":
+			if err := schema.Validate(s); err != nil {
+				fmt.Println("Error:", err)
+				continue
+			}
+			if s.Version == 0 {
+				s.Version = 1
+			}
+			s.Version++
+			if err := schema.SaveSchemaFile(s); err != nil {
+				return err
+			}
+			statusf("Wrote %s", schema.Path(s.Name))
+			return nil
+		case "Cancel":
+			confirmed := false
+			if err := huh.NewConfirm().Title("Discard all changes?").Value(&confirmed).Run(); err != nil {
+				return err
+			}
+			if confirmed {
+				return nil
+			}
+		}
+	}
+}
+
+// printFieldList renders s's fields the same way describe table does, so
+// the editor's menu always shows the current state before prompting for
+// the next action.
+func printFieldList(s schema.TableSchema) {
+	fmt.Printf("%-20s %-12s %-10s %-8s %s\n", "FIELD", "TYPE", "REQUIRED", "UNIQUE", "AUTO")
+	for _, name := range s.FieldOrder() {
+		f := s.Fields[name]
+		fmt.Printf("%-20s %-12s %-10t %-8t %t\n", name, f.DataType, f.Required, f.Unique, f.Automatic)
+	}
+}
+
+// editAddField prompts for a new field and appends it to s.Fields/Order,
+// mirroring the field-add prompts in init table.
+func editAddField(s *schema.TableSchema) error {
+	var fieldName, fieldType string
+	var fieldRequired, fieldUnique bool
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().Title("Field Name").Value(&fieldName).Validate(func(v string) error {
+				if err := schema.ValidateIdentifier(v); err != nil {
+					return err
+				}
+				if _, exists := s.Fields[v]; exists {
+					return fmt.Errorf("field %q already exists", v)
+				}
+				return nil
+			}),
+			huh.NewSelect[string]().Title("Type").Options(huh.NewOptions(schema.RegisteredDataTypeNames()...)...).Value(&fieldType),
+			huh.NewConfirm().Title("Required?").Value(&fieldRequired),
+			huh.NewConfirm().Title("Unique?").
+				Description("A unique field left optional is still nullable and can hold multiple NULLs.").
+				Value(&fieldUnique),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return err
+	}
+
+	var fieldValues []string
+	var fieldDefault string
+	if schema.DataType(fieldType) == schema.DataTypeEnum {
+		var valuesInput string
+		if err := huh.NewInput().Title("Allowed values (comma-separated)").Value(&valuesInput).Validate(func(v string) error {
+			if len(parseEnumValues(v)) == 0 {
+				return fmt.Errorf("at least one value is required")
+			}
+			return nil
+		}).Run(); err != nil {
+			return err
+		}
+		fieldValues = parseEnumValues(valuesInput)
+
+		wantDefault := false
+		if err := huh.NewConfirm().Title("Set a default?").Value(&wantDefault).Run(); err != nil {
+			return err
+		}
+		if wantDefault {
+			if err := huh.NewSelect[string]().Title("Default").Options(huh.NewOptions(fieldValues...)...).Value(&fieldDefault).Run(); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err := huh.NewInput().Title("Default (optional)").Value(&fieldDefault).Run(); err != nil {
+			return err
+		}
+	}
+
+	var fieldMaxBytes, fieldMaxItems int
+	var err error
+	switch schema.DataType(fieldType) {
+	case schema.DataTypeBytes:
+		if fieldMaxBytes, err = promptOptionalSizeBound("Max bytes (optional)"); err != nil {
+			return err
+		}
+	case schema.DataTypeArray:
+		if fieldMaxItems, err = promptOptionalSizeBound("Max items (optional)"); err != nil {
+			return err
+		}
+	}
+
+	s.Fields[fieldName] = schema.TableField{
+		Id:       uuid.New(),
+		DataType: schema.DataType(fieldType),
+		Required: fieldRequired,
+		Unique:   fieldUnique,
+		Default:  fieldDefault,
+		MaxBytes: fieldMaxBytes,
+		MaxItems: fieldMaxItems,
+		Values:   fieldValues,
+	}
+	s.Order = append(s.FieldOrder(), fieldName)
+	return nil
+}
+
+// editModifyField lets the caller pick an existing field and change its
+// type, required, unique, or default, leaving its Id untouched so
+// references elsewhere keep pointing at the same field.
+func editModifyField(s *schema.TableSchema) error {
+	names := s.FieldOrder()
+	if len(names) == 0 {
+		return fmt.Errorf("table %q has no fields to modify", s.Name)
+	}
+
+	fieldName := ""
+	if err := huh.NewSelect[string]().Title("Field to modify").Options(huh.NewOptions(names...)...).Value(&fieldName).Run(); err != nil {
+		return err
+	}
+
+	f := s.Fields[fieldName]
+	fieldType := string(f.DataType)
+	required, unique := f.Required, f.Unique
+	def := f.Default
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().Title("Type").Options(huh.NewOptions(schema.RegisteredDataTypeNames()...)...).Value(&fieldType),
+			huh.NewConfirm().Title("Required?").Value(&required),
+			huh.NewConfirm().Title("Unique?").Value(&unique),
+			huh.NewInput().Title("Default (optional)").Value(&def),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return err
+	}
+
+	f.DataType = schema.DataType(fieldType)
+	f.Required = required
+	f.Unique = unique
+	f.Default = def
+	s.Fields[fieldName] = f
+	return nil
+}
+
+// editRemoveField deletes a field from s, after the same primary-key and
+// cross-table reference checks remove field enforces.
+func editRemoveField(s *schema.TableSchema) error {
+	names := s.FieldOrder()
+	if len(names) == 0 {
+		return fmt.Errorf("table %q has no fields to remove", s.Name)
+	}
+
+	fieldName := ""
+	if err := huh.NewSelect[string]().Title("Field to remove").Options(huh.NewOptions(names...)...).Value(&fieldName).Run(); err != nil {
+		return err
+	}
+
+	f := s.Fields[fieldName]
+	pk := s.PrimaryKey
+	if len(pk) == 0 {
+		pk = []string{"id"}
+	}
+	for _, pkName := range pk {
+		if pkName == fieldName {
+			return fmt.Errorf("field %q is part of the primary key and cannot be removed", fieldName)
+		}
+	}
+
+	referrers, err := schema.FieldReferrers(s.Name, fieldName)
+	if err != nil {
+		return err
+	}
+	if len(referrers) > 0 {
+		return fmt.Errorf("field %q is referenced by %v and cannot be removed", fieldName, referrers)
+	}
+
+	if f.Automatic {
+		confirmed := false
+		if err := huh.NewConfirm().Title(fmt.Sprintf("%q is automatic, remove it anyway?", fieldName)).Value(&confirmed).Run(); err != nil {
+			return err
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	delete(s.Fields, fieldName)
+	order := make([]string, 0, len(s.Order))
+	for _, n := range s.Order {
+		if n != fieldName {
+			order = append(order, n)
+		}
+	}
+	s.Order = order
+	return nil
+}
+
+// editReorderFields re-reads s's field display order from a single
+// comma-separated input, validated as a permutation of the current
+// fields so a typo can't silently drop one.
+func editReorderFields(s *schema.TableSchema) error {
+	current := s.FieldOrder()
+	input := strings.Join(current, ", ")
+	if err := huh.NewInput().
+		Title("New field order (comma-separated)").
+		Description("Current: " + strings.Join(current, ", ")).
+		Value(&input).
+		Validate(func(v string) error {
+			return validateFieldPermutation(v, current)
+		}).Run(); err != nil {
+		return err
+	}
+
+	var order []string
+	for _, name := range strings.Split(input, ",") {
+		order = append(order, strings.TrimSpace(name))
+	}
+	s.Order = order
+	return nil
+}
+
+// validateFieldPermutation reports whether v, split on commas and
+// trimmed, names exactly the fields in current, once each.
+func validateFieldPermutation(v string, current []string) error {
+	var names []string
+	for _, name := range strings.Split(v, ",") {
+		names = append(names, strings.TrimSpace(name))
+	}
+	if len(names) != len(current) {
+		return fmt.Errorf("expected %d fields, got %d", len(current), len(names))
+	}
+	want := append([]string{}, current...)
+	got := append([]string{}, names...)
+	sort.Strings(want)
+	sort.Strings(got)
+	for i := range want {
+		if want[i] != got[i] {
+			return fmt.Errorf("must name exactly the existing fields: %s", strings.Join(current, ", "))
+		}
+	}
+	return nil
+}
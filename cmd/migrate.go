@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/HoodieCollin/dbexp/migrate"
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/pelletier/go-toml/v2"
+)
+
+const stateFilename = "schema_migrations.toml"
+
+// runMigrateNew diffs the "from" and "to" schema snapshots and writes the
+// resulting up/down migration pair into dir.
+func runMigrateNew(dir, from, to string) error {
+	oldSchema, err := readTableSchema(from)
+	if err != nil {
+		return fmt.Errorf("reading --from schema: %w", err)
+	}
+	newSchema, err := readTableSchema(to)
+	if err != nil {
+		return fmt.Errorf("reading --to schema: %w", err)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating migrations directory: %w", err)
+	}
+
+	version := migrate.NewVersion(time.Now())
+	up, down := migrate.Diff(version, oldSchema, newSchema)
+
+	if len(up.Operations) == 0 {
+		fmt.Println("No schema changes detected; nothing to migrate.")
+		return nil
+	}
+
+	if err := writeMigration(filepath.Join(dir, migrate.UpFilename(version, newSchema.Name)), up); err != nil {
+		return err
+	}
+	if err := writeMigration(filepath.Join(dir, migrate.DownFilename(version, newSchema.Name)), down); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created migration %s for table %q (%d operations)\n", version, newSchema.Name, len(up.Operations))
+	return nil
+}
+
+func runMigrateStatus(dir string) error {
+	entries, err := listMigrations(dir)
+	if err != nil {
+		return err
+	}
+	state, err := loadState(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range migrate.Status(entries, state) {
+		mark := " "
+		if row.Applied {
+			mark = "x"
+		}
+		fmt.Printf("[%s] %s  %s\n", mark, row.Version, row.Table)
+	}
+	return nil
+}
+
+func runMigrateUp(dir, target string, dryRun bool) error {
+	entries, err := listMigrations(dir)
+	if err != nil {
+		return err
+	}
+	state, err := loadState(dir)
+	if err != nil {
+		return err
+	}
+
+	plan, err := migrate.PlanUp(entries, state, target)
+	if err != nil {
+		return err
+	}
+	if len(plan) == 0 {
+		fmt.Println("Nothing to do; already up to date.")
+		return nil
+	}
+
+	for _, version := range plan {
+		table := tableForVersion(entries, version)
+		fmt.Printf("up    %s  %s\n", version, table)
+		if dryRun {
+			continue
+		}
+		state.Apply(version)
+	}
+	if dryRun {
+		return nil
+	}
+	return saveState(dir, state)
+}
+
+func runMigrateDown(dir, target string, dryRun bool) error {
+	entries, err := listMigrations(dir)
+	if err != nil {
+		return err
+	}
+	state, err := loadState(dir)
+	if err != nil {
+		return err
+	}
+
+	plan, err := migrate.PlanDown(entries, state, target)
+	if err != nil {
+		return err
+	}
+	if len(plan) == 0 {
+		fmt.Println("Nothing to do; no applied migrations above target.")
+		return nil
+	}
+
+	for _, version := range plan {
+		table := tableForVersion(entries, version)
+		fmt.Printf("down  %s  %s\n", version, table)
+		if dryRun {
+			continue
+		}
+		state.Unapply(version)
+	}
+	if dryRun {
+		return nil
+	}
+	return saveState(dir, state)
+}
+
+func runMigrateRedo(dir string, dryRun bool) error {
+	state, err := loadState(dir)
+	if err != nil {
+		return err
+	}
+	version, err := migrate.PlanRedo(state)
+	if err != nil {
+		return err
+	}
+	if err := runMigrateDown(dir, prevVersion(state, version), dryRun); err != nil {
+		return err
+	}
+	return runMigrateUp(dir, version, dryRun)
+}
+
+// prevVersion returns the version immediately before version in state, or ""
+// if version is the oldest applied migration.
+func prevVersion(state migrate.State, version string) string {
+	var prev string
+	for _, v := range state.Applied {
+		if v == version {
+			return prev
+		}
+		prev = v
+	}
+	return ""
+}
+
+func tableForVersion(entries []migrate.Entry, version string) string {
+	for _, e := range entries {
+		if e.Version == version {
+			return e.Table
+		}
+	}
+	return ""
+}
+
+func listMigrations(dir string) ([]migrate.Entry, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading migrations directory: %w", err)
+	}
+
+	seen := map[string]bool{}
+	var entries []migrate.Entry
+	for _, f := range files {
+		version, table, ok := migrate.ParseUpFilename(f.Name())
+		if !ok {
+			continue
+		}
+		if seen[version] {
+			continue
+		}
+		seen[version] = true
+		entries = append(entries, migrate.Entry{Version: version, Table: table})
+	}
+	return entries, nil
+}
+
+func loadState(dir string) (migrate.State, error) {
+	path := filepath.Join(dir, stateFilename)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return migrate.State{}, nil
+		}
+		return migrate.State{}, fmt.Errorf("reading migration state: %w", err)
+	}
+	var state migrate.State
+	if err := toml.Unmarshal(data, &state); err != nil {
+		return migrate.State{}, fmt.Errorf("parsing migration state: %w", err)
+	}
+	return state, nil
+}
+
+func saveState(dir string, state migrate.State) error {
+	data, err := toml.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("encoding migration state: %w", err)
+	}
+	return os.WriteFile(filepath.Join(dir, stateFilename), data, 0o644)
+}
+
+func writeMigration(path string, m migrate.Migration) error {
+	data, err := toml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("encoding migration %s: %w", path, err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func readTableSchema(path string) (schema.TableSchema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return schema.TableSchema{}, err
+	}
+	var s schema.TableSchema
+	if err := toml.Unmarshal(data, &s); err != nil {
+		return schema.TableSchema{}, err
+	}
+	return s, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// ErrConflict marks an operation that would collide with something that
+// already exists: a duplicate table, field, or index name. Callers check
+// for it with errors.Is.
+var ErrConflict = errors.New("conflict")
+
+// Exit codes for main's top-level command dispatch. 0 is success; 1 is a
+// generic, unclassified failure. The rest document a specific failure
+// class so a script can tell, say, a missing table from an invalid one
+// without scraping the error text:
+//
+//	2  not found      (schema.ErrNotFound)   e.g. no such table
+//	3  validation     (schema.ErrValidation) e.g. schema fails Validate
+//	4  conflict       (ErrConflict)          e.g. field already exists
+const (
+	exitGeneric    = 1
+	exitNotFound   = 2
+	exitValidation = 3
+	exitConflict   = 4
+)
+
+// exitCode maps err to the exit code documenting its failure class.
+func exitCode(err error) int {
+	switch {
+	case errors.Is(err, ErrConflict):
+		return exitConflict
+	case errors.Is(err, schema.ErrValidation):
+		return exitValidation
+	case errors.Is(err, schema.ErrNotFound):
+		return exitNotFound
+	default:
+		return exitGeneric
+	}
+}
+
+// fail prints err and exits with the code documenting its failure class.
+// It's main's single point of command-error handling, replacing the
+// fmt.Println("Error:", err); os.Exit(1) once repeated at every case.
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "Error:", err)
+	os.Exit(exitCode(err))
+}
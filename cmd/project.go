@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/HoodieCollin/dbexp/project"
+)
+
+// runInitProject writes a new dbexp.toml manifest into the current
+// directory, creating the tables and migrations directories it names.
+func runInitProject(name, defaultDialect string) error {
+	if _, _, err := project.Find("."); err == nil {
+		return fmt.Errorf("project: a %s already governs this directory", project.ManifestFilename)
+	}
+
+	m := project.New(name, defaultDialect)
+
+	if err := os.MkdirAll(m.TablesDir, 0o755); err != nil {
+		return fmt.Errorf("creating tables directory: %w", err)
+	}
+	if err := os.MkdirAll(m.MigrationsDir, 0o755); err != nil {
+		return fmt.Errorf("creating migrations directory: %w", err)
+	}
+
+	if err := project.Save(".", m); err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %s for project %q\n", project.ManifestFilename, name)
+	return nil
+}
+
+// runValidate loads every table in the current project and checks them as a
+// whole: name uniqueness, foreign keys resolving across files, no foreign
+// key cycles, and no duplicate field UUIDs.
+func runValidate() error {
+	m, dir, err := project.Find(".")
+	if err != nil {
+		return err
+	}
+
+	tables, err := project.LoadTables(m, dir)
+	if err != nil {
+		return err
+	}
+
+	if err := project.Validate(tables); err != nil {
+		return err
+	}
+
+	fmt.Printf("%d table(s) valid\n", len(tables))
+	return nil
+}
+
+// resolveTablePath returns the TOML file for table name: inside the current
+// project's tables directory when one exists, or "<name>.toml" in the
+// current directory otherwise.
+func resolveTablePath(name string) string {
+	if m, dir, err := project.Find("."); err == nil {
+		return filepath.Join(m.TablesPath(dir), name+".toml")
+	}
+	return name + ".toml"
+}
+
+// tablesDir returns the current project's tables directory, or "." when
+// there is no project.
+func tablesDir() string {
+	if m, dir, err := project.Find("."); err == nil {
+		return m.TablesPath(dir)
+	}
+	return "."
+}
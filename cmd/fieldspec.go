@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/charmbracelet/huh"
+)
+
+// parseFieldSpec parses a "name:type[:required][:unique]" flag value into a
+// name and TableField, for non-interactive table creation. idGen assigns
+// the field's Id, so callers can pass a seeded IDGenerator for
+// reproducible output.
+func parseFieldSpec(spec string, idGen IDGenerator) (string, schema.TableField, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) < 2 {
+		return "", schema.TableField{}, fmt.Errorf("field spec %q: expected name:type[:required][:unique]", spec)
+	}
+	name, typ := parts[0], parts[1]
+	if err := schema.ValidateIdentifier(name); err != nil {
+		return "", schema.TableField{}, fmt.Errorf("field spec %q: %w", spec, err)
+	}
+
+	id, err := idGen.NewUUID()
+	if err != nil {
+		return "", schema.TableField{}, fmt.Errorf("field spec %q: %w", spec, err)
+	}
+	f := schema.TableField{Id: id, DataType: schema.DataType(typ)}
+	for _, flag := range parts[2:] {
+		switch flag {
+		case "required":
+			f.Required = true
+		case "unique":
+			f.Unique = true
+		default:
+			return "", schema.TableField{}, fmt.Errorf("field spec %q: unknown flag %q", spec, flag)
+		}
+	}
+	return name, f, nil
+}
+
+// promptOptionalSizeBound prompts for an optional non-negative integer
+// size bound (max_bytes, max_items), returning 0, the "unbounded" value,
+// when left blank.
+func promptOptionalSizeBound(title string) (int, error) {
+	input := ""
+	if err := huh.NewInput().Title(title).Value(&input).Validate(func(v string) error {
+		if v == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("must be a whole number")
+		}
+		if n < 0 {
+			return fmt.Errorf("cannot be negative")
+		}
+		return nil
+	}).Run(); err != nil {
+		return 0, err
+	}
+	if input == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(input)
+}
+
+// parseEnumValues splits a comma-separated "allowed values" input into a
+// deduplicated, trimmed list, dropping empty entries. Used by the
+// interactive init table flow when the chosen field type is enum.
+func parseEnumValues(input string) []string {
+	seen := map[string]bool{}
+	var values []string
+	for _, v := range strings.Split(input, ",") {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		values = append(values, v)
+	}
+	return values
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/HoodieCollin/dbexp/dialect"
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/HoodieCollin/dbexp/seed"
+)
+
+// runSeed generates count fake rows for the named table and either inserts
+// them directly (when dsn is set) or prints them in the requested format.
+func runSeed(tableName string, count int, dialectName, dsn, out string) error {
+	table, err := readTableSchema(resolveTablePath(tableName))
+	if err != nil {
+		return fmt.Errorf("reading table schema: %w", err)
+	}
+
+	rows := seed.Generate(table, count)
+
+	if dsn != "" {
+		return insertRows(dialectName, dsn, table, rows)
+	}
+
+	var rendered string
+	switch strings.ToLower(out) {
+	case "csv":
+		rendered, err = seed.ToCSV(table, rows)
+	case "jsonl":
+		rendered, err = seed.ToJSONL(rows)
+	case "sql":
+		d, dErr := dialect.ForName(dialectName)
+		if dErr != nil {
+			return dErr
+		}
+		rendered, err = seed.ToSQL(d, table, rows)
+	default:
+		return fmt.Errorf("seed: unsupported --out %q (want sql, csv, or jsonl)", out)
+	}
+	if err != nil {
+		return fmt.Errorf("rendering rows: %w", err)
+	}
+
+	fmt.Print(rendered)
+	return nil
+}
+
+func insertRows(dialectName, dsn string, table schema.TableSchema, rows []seed.Row) error {
+	d, err := dialect.ForName(dialectName)
+	if err != nil {
+		return err
+	}
+
+	sqlText, err := seed.ToSQL(d, table, rows)
+	if err != nil {
+		return fmt.Errorf("rendering inserts: %w", err)
+	}
+
+	db, err := sql.Open(d.DriverName(), dsn)
+	if err != nil {
+		return fmt.Errorf("opening connection: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	for _, stmt := range strings.Split(strings.TrimSpace(sqlText), "\n") {
+		if stmt == "" {
+			continue
+		}
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("inserting row: %w", err)
+		}
+	}
+
+	fmt.Printf("Inserted %d rows into %q\n", len(rows), table.Name)
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// runFmt rewrites one table's or every table's schema.toml file in
+// canonical form: the same load -> marshal round trip SaveSchemaFile
+// already performs after every other mutating command, just run against a
+// file that may have been hand-edited since. A file that's already
+// canonical round-trips to byte-identical output and is left untouched.
+// Only .toml schema files are in scope, matching every other mutating
+// command's write-path convention (schema.Path always writes .toml).
+func runFmt() error {
+	var tables []string
+	if CLI.Fmt.All {
+		schemas, err := schema.AllSchemas()
+		if err != nil {
+			return err
+		}
+		for _, s := range schemas {
+			if _, err := os.Stat(schema.Path(s.Name)); err == nil {
+				tables = append(tables, s.Name)
+			}
+		}
+	} else {
+		if CLI.Fmt.Table == "" {
+			return fmt.Errorf("fmt: pass a table name or --all")
+		}
+		if _, err := os.Stat(schema.Path(CLI.Fmt.Table)); err != nil {
+			return fmt.Errorf("table %q does not exist", CLI.Fmt.Table)
+		}
+		tables = []string{CLI.Fmt.Table}
+	}
+	sort.Strings(tables)
+
+	changed := 0
+	for _, table := range tables {
+		path := schema.Path(table)
+		before, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		s, err := schema.LoadSchemaFile(table)
+		if err != nil {
+			return err
+		}
+		after, err := schema.MarshalSchema(s, "toml")
+		if err != nil {
+			return err
+		}
+
+		if bytes.Equal(before, after) {
+			continue
+		}
+
+		changed++
+		if CLI.Fmt.DryRun {
+			fmt.Println("would reformat", table)
+			continue
+		}
+		if err := schema.SaveSchemaFile(s); err != nil {
+			return err
+		}
+		fmt.Println("reformatted", table)
+	}
+
+	if changed == 0 {
+		fmt.Println("already canonical")
+	}
+	if CLI.Fmt.DryRun && changed > 0 {
+		return fmt.Errorf("fmt: %d table(s) not canonical", changed)
+	}
+	return nil
+}
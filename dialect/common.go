@@ -0,0 +1,108 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// buildCreateTable renders a CREATE TABLE statement shared by every dialect;
+// only column type mapping and identifier quoting differ between them.
+func buildCreateTable(d Dialect, s schema.TableSchema) (string, error) {
+	names := sortedFieldNames(s.Fields)
+
+	var lines []string
+	var primaryKey string
+	var foreignKeys []string
+
+	for _, name := range names {
+		f := s.Fields[name]
+
+		colType, err := d.MapType(f)
+		if err != nil {
+			return "", fmt.Errorf("column %q: %w", name, err)
+		}
+
+		line := fmt.Sprintf("%s %s", d.QuoteIdent(name), colType)
+		if f.Required {
+			line += " NOT NULL"
+		}
+		if f.Unique {
+			line += " UNIQUE"
+		}
+		if f.Default != "" {
+			line += fmt.Sprintf(" DEFAULT %s", f.Default)
+		}
+		if f.Check != "" && d.SupportsCheck() {
+			line += fmt.Sprintf(" CHECK (%s)", f.Check)
+		}
+		lines = append(lines, line)
+
+		if f.PrimaryKey {
+			primaryKey = d.QuoteIdent(name)
+		}
+		if f.ForeignKey != nil {
+			foreignKeys = append(foreignKeys, foreignKeyConstraint(d, name, *f.ForeignKey))
+		}
+	}
+
+	if primaryKey != "" {
+		lines = append(lines, fmt.Sprintf("PRIMARY KEY (%s)", primaryKey))
+	}
+	lines = append(lines, foreignKeys...)
+
+	return fmt.Sprintf("CREATE TABLE %s (\n\t%s\n)", d.QuoteIdent(s.Name), strings.Join(lines, ",\n\t")), nil
+}
+
+func foreignKeyConstraint(d Dialect, field string, fk schema.ForeignKey) string {
+	constraint := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)",
+		d.QuoteIdent(field), d.QuoteIdent(fk.Table), d.QuoteIdent(fk.Field))
+	if fk.OnUpdate != "" {
+		constraint += " ON UPDATE " + referentialActionSQL(fk.OnUpdate)
+	}
+	if fk.OnDelete != "" {
+		constraint += " ON DELETE " + referentialActionSQL(fk.OnDelete)
+	}
+	return constraint
+}
+
+func referentialActionSQL(a schema.ReferentialAction) string {
+	switch a {
+	case schema.ActionCascade:
+		return "CASCADE"
+	case schema.ActionSetNull:
+		return "SET NULL"
+	case schema.ActionSetDefault:
+		return "SET DEFAULT"
+	case schema.ActionRestrict:
+		return "RESTRICT"
+	default:
+		return "NO ACTION"
+	}
+}
+
+// elementType maps the element type of an array field, e.g. for
+// `array<varchar(32)>` this maps just the `varchar(32)` part.
+func elementType(d Dialect, f schema.TableField) (string, error) {
+	if f.Element == nil {
+		return "", fmt.Errorf("array field has no element type")
+	}
+	elem := f
+	elem.Type = *f.Element
+	elem.Element = nil
+	return d.MapType(elem)
+}
+
+func sortedFieldNames(fields map[string]schema.TableField) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	for i := 1; i < len(names); i++ {
+		for j := i; j > 0 && names[j-1] > names[j]; j-- {
+			names[j-1], names[j] = names[j], names[j-1]
+		}
+	}
+	return names
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// generateSpec is the top-level shape of a bulk table-generation file: an
+// array of table definitions, each built the same way init table builds
+// one, minus the interactive prompts.
+type generateSpec struct {
+	Tables []generateTableSpec `toml:"tables"`
+}
+
+type generateTableSpec struct {
+	Name        string   `toml:"name"`
+	Description string   `toml:"description,omitempty"`
+	Fields      []string `toml:"fields,omitempty"`
+}
+
+// buildGeneratedTable assembles a TableSchema for one generateTableSpec,
+// with the same id/created_at/updated_at scaffold init table adds.
+func buildGeneratedTable(t generateTableSpec, idGen IDGenerator) (schema.TableSchema, error) {
+	tableId, err := idGen.NewUUID()
+	if err != nil {
+		return schema.TableSchema{}, err
+	}
+	idFieldId, err := idGen.NewUUID()
+	if err != nil {
+		return schema.TableSchema{}, err
+	}
+	createdAtId, err := idGen.NewUUID()
+	if err != nil {
+		return schema.TableSchema{}, err
+	}
+	updatedAtId, err := idGen.NewUUID()
+	if err != nil {
+		return schema.TableSchema{}, err
+	}
+
+	fields := map[string]schema.TableField{
+		"id": {
+			Id:          idFieldId,
+			DataType:    schema.DataTypeUUID,
+			Unique:      true,
+			Required:    true,
+			Automatic:   true,
+			UUIDVersion: defaultUUIDVersion,
+		},
+		"created_at": {
+			Id:        createdAtId,
+			DataType:  schema.DataTypeTimestampTZ,
+			Required:  true,
+			Automatic: true,
+			Immutable: true,
+		},
+		"updated_at": {
+			Id:        updatedAtId,
+			DataType:  schema.DataTypeTimestampTZ,
+			Required:  true,
+			Automatic: true,
+			OnUpdate:  true,
+		},
+	}
+	order := []string{"id", "created_at", "updated_at"}
+	for _, spec := range t.Fields {
+		name, f, err := parseFieldSpec(spec, idGen)
+		if err != nil {
+			return schema.TableSchema{}, err
+		}
+		fields[name] = f
+		order = append(order, name)
+	}
+
+	return schema.TableSchema{
+		Id:      tableId,
+		Name:    t.Name,
+		Fields:  fields,
+		Order:   order,
+		Version: 1,
+		Comment: t.Description,
+	}, nil
+}
+
+// runGenerate bulk-creates the tables listed in CLI.Generate.File. Every
+// table is validated before anything is written; if any table fails,
+// the whole batch is rejected and no schema files are written.
+func runGenerate() error {
+	data, err := os.ReadFile(CLI.Generate.File)
+	if err != nil {
+		return err
+	}
+
+	var spec generateSpec
+	if err := toml.Unmarshal(data, &spec); err != nil {
+		return fmt.Errorf("%s: %w", CLI.Generate.File, err)
+	}
+	if len(spec.Tables) == 0 {
+		return fmt.Errorf("%s: no tables listed", CLI.Generate.File)
+	}
+
+	var idGen IDGenerator = randomIDGenerator{}
+
+	var errs []error
+	var built []schema.TableSchema
+	for _, t := range spec.Tables {
+		s, err := buildGeneratedTable(t, idGen)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("table %q: %w", t.Name, err))
+			continue
+		}
+		if err := schema.Validate(s); err != nil {
+			errs = append(errs, fmt.Errorf("table %q: %w", t.Name, err))
+			continue
+		}
+		if _, err := os.Stat(schema.Path(s.Name)); err == nil && !CLI.Generate.Force {
+			errs = append(errs, fmt.Errorf("table %q: %s already exists, pass --force to overwrite: %w", t.Name, schema.Path(s.Name), ErrConflict))
+			continue
+		}
+		built = append(built, s)
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for _, s := range built {
+		if err := schema.VerifyRoundTrip(s, "toml"); err != nil {
+			return fmt.Errorf("table %q: %w", s.Name, err)
+		}
+		data, err := toml.Marshal(s)
+		if err != nil {
+			return err
+		}
+		if err := writeSchemaFile(schema.Path(s.Name), data, CLI.Generate.Force); err != nil {
+			return err
+		}
+		statusf("Wrote %s", schema.Path(s.Name))
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Package dialect translates a schema.TableSchema into DDL for a specific
+// SQL database, so that `dbexp apply` can deploy the same TOML source of
+// truth to postgres, mysql, sqlite, or sqlserver.
+package dialect
+
+import (
+	"fmt"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// Dialect knows how to render schema types and tables as DDL for one
+// database engine.
+type Dialect interface {
+	// Name is the dialect's identifier, as passed to `--dialect`.
+	Name() string
+
+	// DriverName is the database/sql driver name used to open a connection.
+	DriverName() string
+
+	// QuoteIdent quotes an identifier (table or column name) for safe
+	// inclusion in generated SQL.
+	QuoteIdent(name string) string
+
+	// MapType renders a field's schema.DataType (plus any parameters, such
+	// as varchar length) as this dialect's column type.
+	MapType(f schema.TableField) (string, error)
+
+	// CreateTable renders a full CREATE TABLE statement for s, including
+	// column constraints, primary key, and foreign keys.
+	CreateTable(s schema.TableSchema) (string, error)
+
+	// AlterColumn renders an ALTER TABLE statement that changes column's
+	// type to the mapped type of to. Nullability, uniqueness, defaults, and
+	// CHECK constraints of to are not included.
+	AlterColumn(table, column string, to schema.TableField) (string, error)
+
+	// SupportsCheck reports whether this dialect enforces CHECK constraints.
+	SupportsCheck() bool
+
+	// SupportsTransactionalDDL reports whether DDL statements can be wrapped
+	// in a transaction and rolled back on failure.
+	SupportsTransactionalDDL() bool
+}
+
+// ForName returns the Dialect registered under name, e.g. "postgres".
+func ForName(name string) (Dialect, error) {
+	switch name {
+	case "postgres":
+		return Postgres{}, nil
+	case "mysql":
+		return MySQL{}, nil
+	case "sqlite":
+		return SQLite{}, nil
+	case "sqlserver":
+		return SQLServer{}, nil
+	default:
+		return nil, fmt.Errorf("dialect: unknown dialect %q", name)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"math/rand"
+
+	"github.com/google/uuid"
+)
+
+// IDGenerator produces the UUIDs init table assigns to a new table and its
+// fields. The default implementation is non-deterministic; a seeded one
+// makes init table's output reproducible for golden-file tests.
+type IDGenerator interface {
+	NewUUID() (uuid.UUID, error)
+}
+
+// randomIDGenerator is the default IDGenerator: every call is genuinely
+// random, matching init table's behavior before --seed existed.
+type randomIDGenerator struct{}
+
+func (randomIDGenerator) NewUUID() (uuid.UUID, error) {
+	return uuid.New(), nil
+}
+
+// seededIDGenerator produces a deterministic sequence of v4-shaped UUIDs
+// from a math/rand source seeded once, so repeated runs with the same
+// --seed produce byte-identical output.
+type seededIDGenerator struct {
+	rng *rand.Rand
+}
+
+func newSeededIDGenerator(seed int64) *seededIDGenerator {
+	return &seededIDGenerator{rng: rand.New(rand.NewSource(seed))}
+}
+
+func (g *seededIDGenerator) NewUUID() (uuid.UUID, error) {
+	var b [16]byte
+	if _, err := g.rng.Read(b[:]); err != nil {
+		return uuid.UUID{}, err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return uuid.FromBytes(b[:])
+}
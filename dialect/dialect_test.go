@@ -0,0 +1,33 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+func TestQuoteIdentEscapesEmbeddedQuoteChar(t *testing.T) {
+	cases := []struct {
+		name string
+		d    Dialect
+		want string
+	}{
+		{name: `say "hi"`, d: Postgres{}, want: `"say ""hi"""`},
+		{name: `say "hi"`, d: SQLite{}, want: `"say ""hi"""`},
+		{name: "say `hi`", d: MySQL{}, want: "`say ``hi```"},
+		{name: "say ]hi]", d: SQLServer{}, want: "[say ]]hi]]]"},
+	}
+
+	for _, c := range cases {
+		if got := c.d.QuoteIdent(c.name); got != c.want {
+			t.Errorf("%T.QuoteIdent(%q) = %q, want %q", c.d, c.name, got, c.want)
+		}
+	}
+}
+
+func TestEnumTypeEscapesEmbeddedSingleQuote(t *testing.T) {
+	f := schema.TableField{Type: schema.DataTypeEnum, Enum: []string{`it's here`}}
+	if got, want := enumType(f), `enum('it''s here')`; got != want {
+		t.Errorf("enumType() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+func runImportPostgres() error {
+	schemas, err := schema.ImportPostgres(CLI.Import.Postgres.Dsn, func(msg string) {
+		warnf("%s", msg)
+	})
+	if err != nil {
+		return err
+	}
+	for _, s := range schemas {
+		if err := schema.SaveSchemaFile(s); err != nil {
+			return err
+		}
+		statusf("Wrote %s", schema.Path(s.Name))
+	}
+	return nil
+}
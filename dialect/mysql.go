@@ -0,0 +1,84 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// MySQL implements Dialect for MySQL/MariaDB.
+type MySQL struct{}
+
+func (MySQL) Name() string       { return "mysql" }
+func (MySQL) DriverName() string { return "mysql" }
+
+func (MySQL) QuoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func (MySQL) MapType(f schema.TableField) (string, error) {
+	switch f.Type {
+	case schema.DataTypeInt8:
+		return "tinyint", nil
+	case schema.DataTypeInt16:
+		return "smallint", nil
+	case schema.DataTypeInt32:
+		return "int", nil
+	case schema.DataTypeInt64:
+		return "bigint", nil
+	case schema.DataTypeFloat32:
+		return "float", nil
+	case schema.DataTypeFloat64:
+		return "double", nil
+	case schema.DataTypeDecimal:
+		return fmt.Sprintf("decimal(%d,%d)", f.Prec, f.Scale), nil
+	case schema.DataTypeBool:
+		return "boolean", nil
+	case schema.DataTypeText:
+		return "text", nil
+	case schema.DataTypeVarchar:
+		return fmt.Sprintf("varchar(%d)", f.Length), nil
+	case schema.DataTypeBytes:
+		return "blob", nil
+	case schema.DataTypeJSON:
+		return "json", nil
+	case schema.DataTypeEnum:
+		return enumType(f), nil
+	case schema.DataTypeArray:
+		// MySQL has no native array type; arrays are stored as JSON.
+		return "json", nil
+	case schema.DataTypeUUID:
+		return "char(36)", nil
+	case schema.DataTypeTimestamp:
+		return "datetime(6)", nil
+	default:
+		return "", fmt.Errorf("mysql: unsupported data type %q", f.Type)
+	}
+}
+
+func enumType(f schema.TableField) string {
+	s := "enum("
+	for i, v := range f.Enum {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("'%s'", strings.ReplaceAll(v, "'", "''"))
+	}
+	return s + ")"
+}
+
+func (d MySQL) CreateTable(s schema.TableSchema) (string, error) {
+	return buildCreateTable(d, s)
+}
+
+func (d MySQL) AlterColumn(table, column string, to schema.TableField) (string, error) {
+	colType, err := d.MapType(to)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s %s", d.QuoteIdent(table), d.QuoteIdent(column), colType), nil
+}
+
+func (MySQL) SupportsCheck() bool            { return true }
+func (MySQL) SupportsTransactionalDDL() bool { return false }
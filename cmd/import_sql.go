@@ -0,0 +1,27 @@
+package main
+
+import (
+	"os"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+func runImportSQL() error {
+	data, err := os.ReadFile(CLI.Import.SQL.File)
+	if err != nil {
+		return err
+	}
+	schemas, err := schema.ImportSQL(data, func(msg string) {
+		warnf("%s", msg)
+	})
+	if err != nil {
+		return err
+	}
+	for _, s := range schemas {
+		if err := schema.SaveSchemaFile(s); err != nil {
+			return err
+		}
+		statusf("Wrote %s", schema.Path(s.Name))
+	}
+	return nil
+}
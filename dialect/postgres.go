@@ -0,0 +1,74 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// Postgres implements Dialect for PostgreSQL.
+type Postgres struct{}
+
+func (Postgres) Name() string       { return "postgres" }
+func (Postgres) DriverName() string { return "pgx" }
+
+func (Postgres) QuoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (Postgres) MapType(f schema.TableField) (string, error) {
+	switch f.Type {
+	case schema.DataTypeInt8, schema.DataTypeInt16:
+		return "smallint", nil
+	case schema.DataTypeInt32:
+		return "integer", nil
+	case schema.DataTypeInt64:
+		return "bigint", nil
+	case schema.DataTypeFloat32:
+		return "real", nil
+	case schema.DataTypeFloat64:
+		return "double precision", nil
+	case schema.DataTypeDecimal:
+		return fmt.Sprintf("numeric(%d,%d)", f.Prec, f.Scale), nil
+	case schema.DataTypeBool:
+		return "boolean", nil
+	case schema.DataTypeText:
+		return "text", nil
+	case schema.DataTypeVarchar:
+		return fmt.Sprintf("varchar(%d)", f.Length), nil
+	case schema.DataTypeBytes:
+		return "bytea", nil
+	case schema.DataTypeJSON:
+		return "jsonb", nil
+	case schema.DataTypeEnum:
+		return "text", nil
+	case schema.DataTypeArray:
+		elemType, err := elementType(Postgres{}, f)
+		if err != nil {
+			return "", err
+		}
+		return elemType + "[]", nil
+	case schema.DataTypeUUID:
+		return "uuid", nil
+	case schema.DataTypeTimestamp:
+		return "timestamptz", nil
+	default:
+		return "", fmt.Errorf("postgres: unsupported data type %q", f.Type)
+	}
+}
+
+func (d Postgres) CreateTable(s schema.TableSchema) (string, error) {
+	return buildCreateTable(d, s)
+}
+
+func (d Postgres) AlterColumn(table, column string, to schema.TableField) (string, error) {
+	colType, err := d.MapType(to)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s", d.QuoteIdent(table), d.QuoteIdent(column), colType), nil
+}
+
+func (Postgres) SupportsCheck() bool            { return true }
+func (Postgres) SupportsTransactionalDDL() bool { return true }
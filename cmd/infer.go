@@ -0,0 +1,246 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// csvTimeLayouts are the date/time formats inferCSVColumn checks a column's
+// sampled values against, most specific first, before falling back to
+// string.
+var csvTimeLayouts = []struct {
+	layout   string
+	dataType schema.DataType
+}{
+	{time.RFC3339, schema.DataTypeTimestampTZ},
+	{"2006-01-02 15:04:05", schema.DataTypeTimestamp},
+	{"2006-01-02", schema.DataTypeDate},
+}
+
+// inferCSVColumn guesses a DataType from a column's non-empty sampled
+// values: int64 if every value parses as an integer, float64 if every
+// value parses as a number, one of csvTimeLayouts if every value matches
+// it, bool if every value parses as a bool, and string otherwise. An empty
+// or type-ambiguous column falls back to string, reported via ok=false so
+// the caller can warn about it.
+func inferCSVColumn(values []string) (schema.DataType, bool) {
+	if len(values) == 0 {
+		return schema.DataTypeString, false
+	}
+
+	allInt := true
+	allFloat := true
+	allBool := true
+	layoutOK := make([]bool, len(csvTimeLayouts))
+	for i := range layoutOK {
+		layoutOK[i] = true
+	}
+
+	for _, v := range values {
+		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+			allInt = false
+		}
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			allFloat = false
+		}
+		if _, err := strconv.ParseBool(v); err != nil {
+			allBool = false
+		}
+		for i, layout := range csvTimeLayouts {
+			if _, err := time.Parse(layout.layout, v); err != nil {
+				layoutOK[i] = false
+			}
+		}
+	}
+
+	switch {
+	case allInt:
+		return schema.DataTypeInt64, true
+	case allFloat:
+		return schema.DataTypeFloat64, true
+	}
+	for i, layout := range csvTimeLayouts {
+		if layoutOK[i] {
+			return layout.dataType, true
+		}
+	}
+	if allBool {
+		return schema.DataTypeBool, true
+	}
+	return schema.DataTypeString, false
+}
+
+// csvFieldName normalizes a CSV header into a valid identifier: lowercased,
+// with every run of non-identifier characters collapsed to an underscore,
+// and a leading underscore added if it would otherwise start with a digit.
+func csvFieldName(header string) string {
+	var b strings.Builder
+	lastUnderscore := false
+	for _, r := range strings.ToLower(header) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_':
+			b.WriteRune(r)
+			lastUnderscore = r == '_'
+		case !lastUnderscore:
+			b.WriteRune('_')
+			lastUnderscore = true
+		}
+	}
+	name := strings.Trim(b.String(), "_")
+	if name == "" {
+		return name
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// runInferCSV reads CLI.Infer.CSV.File's header and up to --sample data
+// rows, guesses a DataType for each column, and writes the resulting
+// TableSchema the same way init table writes one.
+func runInferCSV() error {
+	f, err := os.Open(CLI.Infer.CSV.File)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("%s: %w", CLI.Infer.CSV.File, err)
+	}
+
+	sample := CLI.Infer.CSV.Sample
+	if sample <= 0 {
+		sample = 50
+	}
+	columns := make([][]string, len(header))
+	rowCount := 0
+	present := make([]int, len(header))
+	for rowCount < sample {
+		record, err := r.Read()
+		if err != nil {
+			break
+		}
+		rowCount++
+		for i := range header {
+			if i >= len(record) {
+				continue
+			}
+			v := strings.TrimSpace(record[i])
+			if v == "" {
+				continue
+			}
+			columns[i] = append(columns[i], v)
+			present[i]++
+		}
+	}
+
+	name := CLI.Infer.CSV.Table
+	if name == "" {
+		base := filepath.Base(CLI.Infer.CSV.File)
+		name = csvFieldName(base[:len(base)-len(filepath.Ext(base))])
+	}
+
+	var idGen IDGenerator = randomIDGenerator{}
+	tableId, err := idGen.NewUUID()
+	if err != nil {
+		return err
+	}
+	fields := map[string]schema.TableField{}
+	var order []string
+
+	if !CLI.Infer.CSV.NoAuto {
+		idFieldId, err := idGen.NewUUID()
+		if err != nil {
+			return err
+		}
+		createdAtId, err := idGen.NewUUID()
+		if err != nil {
+			return err
+		}
+		updatedAtId, err := idGen.NewUUID()
+		if err != nil {
+			return err
+		}
+		fields["id"] = schema.TableField{Id: idFieldId, DataType: schema.DataTypeUUID, Unique: true, Required: true, Automatic: true, UUIDVersion: defaultUUIDVersion}
+		fields["created_at"] = schema.TableField{Id: createdAtId, DataType: schema.DataTypeTimestampTZ, Required: true, Automatic: true, Immutable: true}
+		fields["updated_at"] = schema.TableField{Id: updatedAtId, DataType: schema.DataTypeTimestampTZ, Required: true, Automatic: true, OnUpdate: true}
+		order = append(order, "id", "created_at", "updated_at")
+	}
+
+	seen := map[string]bool{}
+	for i, rawName := range header {
+		colName := csvFieldName(rawName)
+		if colName == "" || schema.ValidateIdentifier(colName) != nil {
+			return fmt.Errorf("column %d %q: could not derive a valid field name", i+1, rawName)
+		}
+		if seen[colName] || fields[colName].DataType != "" {
+			return fmt.Errorf("column %d %q: normalizes to field name %q, which collides with another column", i+1, rawName, colName)
+		}
+		seen[colName] = true
+
+		fieldId, err := idGen.NewUUID()
+		if err != nil {
+			return err
+		}
+		dataType, ok := inferCSVColumn(columns[i])
+		if !ok {
+			warnf("column %q: ambiguous or empty sample data, defaulting to string", rawName)
+		}
+		fields[colName] = schema.TableField{
+			Id:       fieldId,
+			DataType: dataType,
+			Required: rowCount > 0 && present[i] == rowCount,
+		}
+		order = append(order, colName)
+	}
+
+	s := schema.TableSchema{Id: tableId, Name: name, Fields: fields, Order: order, Version: 1}
+	if err := schema.Validate(s); err != nil {
+		return err
+	}
+
+	format := CLI.Infer.CSV.Format
+	if format == "" {
+		format = CLI.DefaultFormat
+	}
+	if format == "" {
+		format = "toml"
+	}
+	cfg, err := schema.MarshalSchema(s, format)
+	if err != nil {
+		return err
+	}
+
+	out := CLI.Infer.CSV.Output
+	if out == "" {
+		out = schema.Path(name)
+		if ext := schema.FormatExt(format); ext != ".toml" {
+			out = out[:len(out)-len(filepath.Ext(out))] + ext
+		}
+	}
+	if out == "-" || CLI.Infer.CSV.DryRun {
+		fmt.Println(string(cfg))
+		if CLI.Infer.CSV.DryRun {
+			fmt.Println("Would write", out)
+		}
+		return nil
+	}
+
+	if err := writeSchemaFile(out, cfg, CLI.Infer.CSV.Force); err != nil {
+		return err
+	}
+	statusf("Wrote %s", out)
+	return nil
+}
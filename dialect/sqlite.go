@@ -0,0 +1,58 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// SQLite implements Dialect for SQLite. SQLite has no native ALTER COLUMN,
+// so AlterColumn returns an error directing the caller to the
+// recreate-and-copy workaround instead of silently producing invalid SQL.
+type SQLite struct{}
+
+func (SQLite) Name() string       { return "sqlite" }
+func (SQLite) DriverName() string { return "sqlite3" }
+
+func (SQLite) QuoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (SQLite) MapType(f schema.TableField) (string, error) {
+	switch f.Type {
+	case schema.DataTypeInt8, schema.DataTypeInt16, schema.DataTypeInt32, schema.DataTypeInt64:
+		return "integer", nil
+	case schema.DataTypeFloat32, schema.DataTypeFloat64:
+		return "real", nil
+	case schema.DataTypeDecimal:
+		return "numeric", nil
+	case schema.DataTypeBool:
+		return "boolean", nil
+	case schema.DataTypeText, schema.DataTypeEnum:
+		return "text", nil
+	case schema.DataTypeVarchar:
+		return fmt.Sprintf("varchar(%d)", f.Length), nil
+	case schema.DataTypeBytes:
+		return "blob", nil
+	case schema.DataTypeJSON, schema.DataTypeArray:
+		return "text", nil
+	case schema.DataTypeUUID:
+		return "text", nil
+	case schema.DataTypeTimestamp:
+		return "datetime", nil
+	default:
+		return "", fmt.Errorf("sqlite: unsupported data type %q", f.Type)
+	}
+}
+
+func (d SQLite) CreateTable(s schema.TableSchema) (string, error) {
+	return buildCreateTable(d, s)
+}
+
+func (SQLite) AlterColumn(table, column string, to schema.TableField) (string, error) {
+	return "", fmt.Errorf("sqlite: column %q on table %q cannot be altered in place; recreate the table and copy its data", column, table)
+}
+
+func (SQLite) SupportsCheck() bool            { return true }
+func (SQLite) SupportsTransactionalDDL() bool { return true }
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/charmbracelet/huh"
+	"github.com/mattn/go-isatty"
+)
+
+// isInteractive reports whether both stdin and stdout are attached to a
+// terminal, so a confirmation prompt only fires when there's a human
+// around to answer it.
+func isInteractive() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) && isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// writeSchemaFile writes data to path, the single policy every command
+// that can clobber a hand-edited file goes through: refuse and exit
+// non-zero unless force is set, and even with force, ask for confirmation
+// when running interactively, so --force baked into a script still
+// overwrites without a human in the loop.
+func writeSchemaFile(path string, data []byte, force bool) error {
+	if _, err := os.Stat(path); err == nil {
+		if !force {
+			return fmt.Errorf("%s already exists, pass --force to overwrite: %w", path, ErrConflict)
+		}
+		if isInteractive() {
+			overwrite := false
+			if err := huh.NewConfirm().Title(fmt.Sprintf("Overwrite %s?", path)).Value(&overwrite).Run(); err != nil {
+				return err
+			}
+			if !overwrite {
+				return fmt.Errorf("%s: not overwritten", path)
+			}
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
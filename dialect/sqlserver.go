@@ -0,0 +1,68 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// SQLServer implements Dialect for Microsoft SQL Server.
+type SQLServer struct{}
+
+func (SQLServer) Name() string       { return "sqlserver" }
+func (SQLServer) DriverName() string { return "sqlserver" }
+
+func (SQLServer) QuoteIdent(name string) string {
+	return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+}
+
+func (SQLServer) MapType(f schema.TableField) (string, error) {
+	switch f.Type {
+	case schema.DataTypeInt8:
+		return "tinyint", nil
+	case schema.DataTypeInt16:
+		return "smallint", nil
+	case schema.DataTypeInt32:
+		return "int", nil
+	case schema.DataTypeInt64:
+		return "bigint", nil
+	case schema.DataTypeFloat32:
+		return "real", nil
+	case schema.DataTypeFloat64:
+		return "float", nil
+	case schema.DataTypeDecimal:
+		return fmt.Sprintf("decimal(%d,%d)", f.Prec, f.Scale), nil
+	case schema.DataTypeBool:
+		return "bit", nil
+	case schema.DataTypeText, schema.DataTypeEnum:
+		return "nvarchar(max)", nil
+	case schema.DataTypeVarchar:
+		return fmt.Sprintf("nvarchar(%d)", f.Length), nil
+	case schema.DataTypeBytes:
+		return "varbinary(max)", nil
+	case schema.DataTypeJSON, schema.DataTypeArray:
+		return "nvarchar(max)", nil
+	case schema.DataTypeUUID:
+		return "uniqueidentifier", nil
+	case schema.DataTypeTimestamp:
+		return "datetime2", nil
+	default:
+		return "", fmt.Errorf("sqlserver: unsupported data type %q", f.Type)
+	}
+}
+
+func (d SQLServer) CreateTable(s schema.TableSchema) (string, error) {
+	return buildCreateTable(d, s)
+}
+
+func (d SQLServer) AlterColumn(table, column string, to schema.TableField) (string, error) {
+	colType, err := d.MapType(to)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s", d.QuoteIdent(table), d.QuoteIdent(column), colType), nil
+}
+
+func (SQLServer) SupportsCheck() bool            { return true }
+func (SQLServer) SupportsTransactionalDDL() bool { return true }
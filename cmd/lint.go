@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// LintConfig is dbexp.toml's [lint] section. A rule named in Rules is
+// forced on (true) or off (false); a rule it doesn't mention defaults to
+// enabled.
+type LintConfig struct {
+	Rules map[string]bool `toml:"rules,omitempty"`
+}
+
+// lintRuleConfig is the rule-name -> enabled override loaded from
+// dbexp.toml by loadProjectConfig. It starts empty, which leaves every
+// rule enabled until a project file says otherwise.
+var lintRuleConfig = map[string]bool{}
+
+// lintRuleEnabled reports whether name should run, honoring
+// lintRuleConfig's override when one is set.
+func lintRuleEnabled(name string) bool {
+	enabled, ok := lintRuleConfig[name]
+	return !ok || enabled
+}
+
+// runLint checks one table or every table in schema.Dir against
+// schema.LintRules, printing each finding and failing (non-zero exit, via
+// the returned error) when any enabled rule produced an error-severity
+// finding.
+func runLint() error {
+	var tables []schema.TableSchema
+	if CLI.Lint.All {
+		schemas, err := schema.AllSchemas()
+		if err != nil {
+			return err
+		}
+		tables = schemas
+	} else {
+		if CLI.Lint.Table == "" {
+			return fmt.Errorf("lint: pass a table name or --all")
+		}
+		s, err := schema.LoadSchemaByName(CLI.Lint.Table)
+		if err != nil {
+			return err
+		}
+		tables = []schema.TableSchema{s}
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Name < tables[j].Name })
+
+	var findings []schema.LintFinding
+	for _, s := range tables {
+		for _, rule := range schema.LintRules {
+			if lintRuleEnabled(rule.Name) {
+				findings = append(findings, rule.Check(s)...)
+			}
+		}
+	}
+
+	if CLI.JSON {
+		if findings == nil {
+			findings = []schema.LintFinding{}
+		}
+		if err := printJSON(findings); err != nil {
+			return err
+		}
+	} else if len(findings) == 0 {
+		fmt.Println("no findings")
+	} else {
+		for _, f := range findings {
+			fmt.Println(f)
+		}
+	}
+
+	errorCount := 0
+	for _, f := range findings {
+		if f.Severity == schema.LintError {
+			errorCount++
+		}
+	}
+	if errorCount > 0 {
+		return fmt.Errorf("lint: %d error(s) found", errorCount)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// statusf prints a diagnostic progress message (e.g. "Wrote <path>") to
+// stderr, so it never contaminates a command's real output (TOML, JSON,
+// DDL) on stdout. Suppressed by --quiet.
+func statusf(format string, args ...any) {
+	if CLI.Quiet {
+		return
+	}
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
+
+// warnf prints a non-fatal warning to stderr, such as a lossy import or a
+// skipped file. Suppressed by --quiet, same as statusf.
+func warnf(format string, args ...any) {
+	if CLI.Quiet {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "warning: "+format+"\n", args...)
+}
+
+// verbosef prints extra diagnostic detail that's only useful with
+// --verbose, and is suppressed otherwise (including under --quiet).
+func verbosef(format string, args ...any) {
+	if !CLI.Verbose || CLI.Quiet {
+		return
+	}
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
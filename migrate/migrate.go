@@ -0,0 +1,174 @@
+// Package migrate computes and stores schema migrations: versioned pairs of
+// up/down operation lists derived from diffing two schema.TableSchema
+// snapshots, laid out on disk in the golang-migrate style (one timestamped
+// pair of files per migration, plus a small state file recording which
+// versions have been applied).
+package migrate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// OpKind identifies the kind of change a single Operation makes.
+type OpKind string
+
+const (
+	OpAddField       OpKind = "add_field"
+	OpDropField      OpKind = "drop_field"
+	OpRenameField    OpKind = "rename_field"
+	OpChangeType     OpKind = "change_type"
+	OpAddIndex       OpKind = "add_index"
+	OpDropIndex      OpKind = "drop_index"
+	OpAddForeignKey  OpKind = "add_foreign_key"
+	OpDropForeignKey OpKind = "drop_foreign_key"
+)
+
+// Operation is a single, reversible change to a table.
+type Operation struct {
+	Kind  OpKind `toml:"kind"`
+	Field string `toml:"field"`
+
+	// RenameFrom/RenameTo are only set for OpRenameField.
+	RenameFrom string `toml:"rename_from,omitempty"`
+	RenameTo   string `toml:"rename_to,omitempty"`
+
+	// Definition is the field as it looks after the operation is applied
+	// (add_field, change_type) or as it looked before it was applied
+	// (drop_field, used to generate the down operation).
+	Definition *schema.TableField `toml:"definition,omitempty"`
+}
+
+// Migration is one direction (up or down) of a versioned change to a table.
+type Migration struct {
+	Version    string      `toml:"version"`
+	Table      string      `toml:"table"`
+	Operations []Operation `toml:"operations"`
+}
+
+// NewVersion returns a golang-migrate style version string for the current
+// time, e.g. "20240304102707".
+func NewVersion(now time.Time) string {
+	return now.UTC().Format("20060102150405")
+}
+
+// UpFilename and DownFilename return the on-disk names for a migration's two
+// halves, e.g. "20240304102707_add_users.up.toml".
+func UpFilename(version, table string) string {
+	return fmt.Sprintf("%s_%s.up.toml", version, table)
+}
+
+func DownFilename(version, table string) string {
+	return fmt.Sprintf("%s_%s.down.toml", version, table)
+}
+
+// ParseUpFilename extracts the version and table name from an up migration
+// filename, returning ok=false if name doesn't look like one.
+func ParseUpFilename(name string) (version, table string, ok bool) {
+	return parseFilename(name, ".up.toml")
+}
+
+// ParseDownFilename extracts the version and table name from a down
+// migration filename, returning ok=false if name doesn't look like one.
+func ParseDownFilename(name string) (version, table string, ok bool) {
+	return parseFilename(name, ".down.toml")
+}
+
+func parseFilename(name, suffix string) (version, table string, ok bool) {
+	if !strings.HasSuffix(name, suffix) {
+		return "", "", false
+	}
+	base := strings.TrimSuffix(name, suffix)
+	version, table, found := strings.Cut(base, "_")
+	if !found || version == "" || table == "" {
+		return "", "", false
+	}
+	return version, table, true
+}
+
+// Diff compares two snapshots of the same table and returns the up migration
+// (old -> new) and its inverse down migration (new -> old). Fields are
+// matched by UUID rather than name so that a renamed field produces a single
+// rename_field operation instead of a drop+add pair.
+func Diff(version string, old, new schema.TableSchema) (up, down Migration) {
+	oldByID := make(map[string]fieldRef, len(old.Fields))
+	for name, f := range old.Fields {
+		oldByID[f.Id.String()] = fieldRef{name: name, field: f}
+	}
+	newByID := make(map[string]fieldRef, len(new.Fields))
+	for name, f := range new.Fields {
+		newByID[f.Id.String()] = fieldRef{name: name, field: f}
+	}
+
+	up = Migration{Version: version, Table: new.Name}
+	down = Migration{Version: version, Table: old.Name}
+
+	for id, n := range newByID {
+		o, existed := oldByID[id]
+		switch {
+		case !existed:
+			def := n.field
+			up.Operations = append(up.Operations, Operation{Kind: OpAddField, Field: n.name, Definition: &def})
+			down.Operations = append(down.Operations, Operation{Kind: OpDropField, Field: n.name, Definition: &def})
+
+		case o.name != n.name:
+			up.Operations = append(up.Operations, Operation{Kind: OpRenameField, Field: n.name, RenameFrom: o.name, RenameTo: n.name})
+			down.Operations = append(down.Operations, Operation{Kind: OpRenameField, Field: o.name, RenameFrom: n.name, RenameTo: o.name})
+			fallthrough
+
+		default:
+			if !sameType(o.field, n.field) {
+				oldDef, newDef := o.field, n.field
+				up.Operations = append(up.Operations, Operation{Kind: OpChangeType, Field: n.name, Definition: &newDef})
+				down.Operations = append(down.Operations, Operation{Kind: OpChangeType, Field: o.name, Definition: &oldDef})
+			}
+			if n.field.Index && !o.field.Index {
+				up.Operations = append(up.Operations, Operation{Kind: OpAddIndex, Field: n.name})
+				down.Operations = append(down.Operations, Operation{Kind: OpDropIndex, Field: n.name})
+			}
+			if !n.field.Index && o.field.Index {
+				up.Operations = append(up.Operations, Operation{Kind: OpDropIndex, Field: n.name})
+				down.Operations = append(down.Operations, Operation{Kind: OpAddIndex, Field: n.name})
+			}
+			if n.field.ForeignKey != nil && o.field.ForeignKey == nil {
+				up.Operations = append(up.Operations, Operation{Kind: OpAddForeignKey, Field: n.name})
+				down.Operations = append(down.Operations, Operation{Kind: OpDropForeignKey, Field: n.name})
+			}
+			if n.field.ForeignKey == nil && o.field.ForeignKey != nil {
+				up.Operations = append(up.Operations, Operation{Kind: OpDropForeignKey, Field: n.name})
+				down.Operations = append(down.Operations, Operation{Kind: OpAddForeignKey, Field: n.name})
+			}
+		}
+	}
+
+	for id, o := range oldByID {
+		if _, stillPresent := newByID[id]; !stillPresent {
+			def := o.field
+			up.Operations = append(up.Operations, Operation{Kind: OpDropField, Field: o.name, Definition: &def})
+			down.Operations = append(down.Operations, Operation{Kind: OpAddField, Field: o.name, Definition: &def})
+		}
+	}
+
+	return up, down
+}
+
+type fieldRef struct {
+	name  string
+	field schema.TableField
+}
+
+func sameType(a, b schema.TableField) bool {
+	if a.Type != b.Type {
+		return false
+	}
+	switch a.Type {
+	case schema.DataTypeVarchar:
+		return a.Length == b.Length
+	case schema.DataTypeDecimal:
+		return a.Prec == b.Prec && a.Scale == b.Scale
+	}
+	return true
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// migrationStatePath is where the last-migrated fingerprint of each table
+// is recorded, so `migrate status` can tell a generated/applied migration
+// apart from a schema edit nothing has been generated for yet.
+const migrationStatePath = ".dbexp/state.toml"
+
+// MigrationState is migrationStatePath's on-disk shape: table name to the
+// Fingerprint() recorded the last time a migration was generated for it.
+type MigrationState struct {
+	Fingerprints map[string]string `toml:"fingerprints"`
+}
+
+// loadMigrationState reads migrationStatePath, returning an empty state
+// (every table reported dirty) when it doesn't exist yet.
+func loadMigrationState() (MigrationState, error) {
+	var state MigrationState
+	data, err := os.ReadFile(migrationStatePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return state, err
+	}
+	if err := toml.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("%s: %w", migrationStatePath, err)
+	}
+	return state, nil
+}
+
+// saveMigrationState writes state to migrationStatePath, creating its
+// parent directory if needed.
+func saveMigrationState(state MigrationState) error {
+	if err := os.MkdirAll(filepath.Dir(migrationStatePath), 0o755); err != nil {
+		return err
+	}
+	data, err := toml.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(migrationStatePath, data, 0o644)
+}
+
+// recordMigrationState stamps table's current fingerprint into
+// migrationStatePath, marking it clean as of the migration just generated.
+func recordMigrationState(table, fingerprint string) error {
+	state, err := loadMigrationState()
+	if err != nil {
+		return err
+	}
+	if state.Fingerprints == nil {
+		state.Fingerprints = map[string]string{}
+	}
+	state.Fingerprints[table] = fingerprint
+	return saveMigrationState(state)
+}
+
+// migrateStatusResult is the JSON shape of one row of `migrate status
+// --json`.
+type migrateStatusResult struct {
+	Table string `json:"table"`
+	Dirty bool   `json:"dirty"`
+}
+
+// runMigrateStatus compares each table's current Fingerprint() against the
+// one recorded in migrationStatePath, reporting clean/dirty per table and
+// failing (non-zero exit) when any table is dirty.
+func runMigrateStatus() error {
+	var tables []string
+	if CLI.Migrate.Status.All {
+		schemas, err := schema.AllSchemas()
+		if err != nil {
+			return err
+		}
+		for _, s := range schemas {
+			tables = append(tables, s.Name)
+		}
+	} else {
+		if CLI.Migrate.Status.Table == "" {
+			return fmt.Errorf("migrate status: pass a table name or --all")
+		}
+		tables = []string{CLI.Migrate.Status.Table}
+	}
+	sort.Strings(tables)
+
+	state, err := loadMigrationState()
+	if err != nil {
+		return err
+	}
+
+	dirty := 0
+	results := make([]migrateStatusResult, 0, len(tables))
+	for _, table := range tables {
+		s, err := schema.LoadSchemaByName(table)
+		if err != nil {
+			return err
+		}
+		isDirty := s.Fingerprint() != state.Fingerprints[table]
+		if isDirty {
+			dirty++
+		}
+		results = append(results, migrateStatusResult{Table: table, Dirty: isDirty})
+		if !CLI.JSON {
+			status := "clean"
+			if isDirty {
+				status = "dirty"
+			}
+			fmt.Printf("%s: %s\n", table, status)
+		}
+	}
+	if CLI.JSON {
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	}
+	if dirty > 0 {
+		return fmt.Errorf("migrate status: %d of %d table(s) have pending changes", dirty, len(tables))
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package migrate
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/google/uuid"
+)
+
+// TestDiffDetectsRenameByFieldUUID verifies that a field kept under the same
+// Id but given a new name produces a single rename_field operation, the
+// headline behavior request #2 asked for so that renames survive instead of
+// diffing as a drop+add pair.
+func TestDiffDetectsRenameByFieldUUID(t *testing.T) {
+	fieldId := uuid.New()
+	old := schema.TableSchema{
+		Id:   uuid.New(),
+		Name: "users",
+		Fields: map[string]schema.TableField{
+			"email_addr": {Id: fieldId, Type: schema.DataTypeVarchar, Length: 255},
+		},
+	}
+	new := old
+	new.Fields = map[string]schema.TableField{
+		"email": {Id: fieldId, Type: schema.DataTypeVarchar, Length: 255},
+	}
+
+	up, down := Diff("v1", old, new)
+
+	if got, want := opKinds(up.Operations), []OpKind{OpRenameField}; !sameKinds(got, want) {
+		t.Fatalf("up operations = %v, want %v", got, want)
+	}
+	if op := up.Operations[0]; op.RenameFrom != "email_addr" || op.RenameTo != "email" {
+		t.Fatalf("up rename = %+v, want from email_addr to email", op)
+	}
+	if got, want := opKinds(down.Operations), []OpKind{OpRenameField}; !sameKinds(got, want) {
+		t.Fatalf("down operations = %v, want %v", got, want)
+	}
+	if op := down.Operations[0]; op.RenameFrom != "email" || op.RenameTo != "email_addr" {
+		t.Fatalf("down rename = %+v, want from email to email_addr", op)
+	}
+}
+
+// TestDiffDetectsTypeIndexAndForeignKeyChangeInSameIteration verifies that a
+// field can pick up a type change, a new index, and a new foreign key all in
+// the same Diff call without any of them being dropped, since all three fall
+// through the same "default" branch for a field whose name didn't change.
+func TestDiffDetectsTypeIndexAndForeignKeyChangeInSameIteration(t *testing.T) {
+	fieldId := uuid.New()
+	old := schema.TableSchema{
+		Id:   uuid.New(),
+		Name: "posts",
+		Fields: map[string]schema.TableField{
+			"author": {Id: fieldId, Type: schema.DataTypeVarchar, Length: 64},
+		},
+	}
+	new := old
+	new.Fields = map[string]schema.TableField{
+		"author": {
+			Id:    fieldId,
+			Type:  schema.DataTypeUUID,
+			Index: true,
+			ForeignKey: &schema.ForeignKey{
+				Table: "users",
+				Field: "id",
+			},
+		},
+	}
+
+	up, down := Diff("v1", old, new)
+
+	wantUp := []OpKind{OpChangeType, OpAddIndex, OpAddForeignKey}
+	if got := opKinds(up.Operations); !sameKinds(got, wantUp) {
+		t.Fatalf("up operations = %v, want %v", got, wantUp)
+	}
+
+	wantDown := []OpKind{OpChangeType, OpDropIndex, OpDropForeignKey}
+	if got := opKinds(down.Operations); !sameKinds(got, wantDown) {
+		t.Fatalf("down operations = %v, want %v", got, wantDown)
+	}
+}
+
+// opKinds returns the Kind of each operation, sorted for comparison since
+// Diff iterates a map and doesn't guarantee operation order.
+func opKinds(ops []Operation) []OpKind {
+	kinds := make([]OpKind, len(ops))
+	for i, op := range ops {
+		kinds[i] = op.Kind
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	return kinds
+}
+
+func sameKinds(got, want []OpKind) bool {
+	sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// runCheckNames reports every table and field name that doesn't match
+// CLI.Check.Names.Convention, and with --fix, renames the offenders and
+// updates any foreign key that pointed at the old name.
+func runCheckNames() error {
+	convention := CLI.Check.Names.Convention
+
+	schemas, err := schema.AllSchemas()
+	if err != nil {
+		return err
+	}
+
+	violations, err := schema.CheckNames(schemas, convention)
+	if err != nil {
+		return err
+	}
+
+	if CLI.Check.Names.Fix {
+		fixed, ferr := fixNames(violations)
+		if ferr != nil {
+			return ferr
+		}
+		// Renaming settles every flagged name in one pass, so the only
+		// violations left to report are ones the fix couldn't make (an
+		// identifier collision, most likely).
+		violations = fixed
+	}
+
+	if CLI.JSON {
+		if violations == nil {
+			violations = []schema.NamingViolation{}
+		}
+		if err := printJSON(violations); err != nil {
+			return err
+		}
+	} else if len(violations) == 0 {
+		fmt.Println("no naming violations found")
+	} else {
+		for _, v := range violations {
+			fmt.Println(v)
+		}
+	}
+
+	if len(violations) > 0 {
+		return fmt.Errorf("check names: %d violation(s) found", len(violations))
+	}
+	return nil
+}
+
+// fixNames renames every table or field in violations to its suggested
+// name, updating any other table's References or ForeignKeys that
+// pointed at it, and returns the violations that couldn't be fixed.
+//
+// Tables are renamed before fields, and field violations are resolved
+// against the post-rename table name, so a table flagged alongside its
+// own fields (e.g. "UserAccount" and "UserAccount.FirstName" in the same
+// run) doesn't send the field rename looking for a schema file that
+// renameTable already moved out from under it.
+func fixNames(violations []schema.NamingViolation) ([]schema.NamingViolation, error) {
+	renamedTables := map[string]string{}
+	var remaining []schema.NamingViolation
+
+	for _, v := range violations {
+		table, _, isField := splitLocation(v.Location)
+		if isField {
+			continue
+		}
+		if err := renameTable(table, v.Suggested, true); err != nil {
+			warnf("check names: couldn't rename %q to %q: %v", v.Name, v.Suggested, err)
+			remaining = append(remaining, v)
+			continue
+		}
+		renamedTables[table] = v.Suggested
+	}
+
+	for _, v := range violations {
+		table, field, isField := splitLocation(v.Location)
+		if !isField {
+			continue
+		}
+		if newTable, ok := renamedTables[table]; ok {
+			table = newTable
+		}
+		if err := renameField(table, field, v.Suggested, true, false); err != nil {
+			warnf("check names: couldn't rename %q to %q: %v", v.Name, v.Suggested, err)
+			remaining = append(remaining, v)
+		}
+	}
+
+	return remaining, nil
+}
+
+// splitLocation breaks a NamingViolation.Location back into its table and,
+// if present, field name.
+func splitLocation(location string) (table, field string, isField bool) {
+	for i := 0; i < len(location); i++ {
+		if location[i] == '.' {
+			return location[:i], location[i+1:], true
+		}
+	}
+	return location, "", false
+}
@@ -0,0 +1,99 @@
+package migrate
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Entry describes one migration pair discovered on disk.
+type Entry struct {
+	Version string
+	Table   string
+}
+
+// StatusRow reports whether a discovered migration has been applied.
+type StatusRow struct {
+	Entry
+	Applied bool
+}
+
+// Status returns every known migration in version order, annotated with
+// whether it has been applied according to state.
+func Status(entries []Entry, state State) []StatusRow {
+	sorted := sortedEntries(entries)
+	rows := make([]StatusRow, len(sorted))
+	for i, e := range sorted {
+		rows[i] = StatusRow{Entry: e, Applied: state.Contains(e.Version)}
+	}
+	return rows
+}
+
+// PlanUp returns the versions, in ascending order, that need to be applied to
+// go from the current state to target. An empty target means "apply
+// everything not yet applied".
+func PlanUp(entries []Entry, state State, target string) ([]string, error) {
+	sorted := sortedEntries(entries)
+	if target != "" && !containsVersion(sorted, target) {
+		return nil, fmt.Errorf("migrate: unknown target version %q", target)
+	}
+
+	var plan []string
+	for _, e := range sorted {
+		if !state.Contains(e.Version) {
+			plan = append(plan, e.Version)
+		}
+		if target != "" && e.Version == target {
+			break
+		}
+	}
+	return plan, nil
+}
+
+// PlanDown returns the versions, in descending order, that need to be
+// unapplied to go from the current state down to target. An empty target
+// means "unapply everything that has been applied".
+func PlanDown(entries []Entry, state State, target string) ([]string, error) {
+	sorted := sortedEntries(entries)
+	if target != "" && !containsVersion(sorted, target) {
+		return nil, fmt.Errorf("migrate: unknown target version %q", target)
+	}
+
+	var plan []string
+	for i := len(sorted) - 1; i >= 0; i-- {
+		e := sorted[i]
+		if !state.Contains(e.Version) {
+			continue
+		}
+		if target != "" && e.Version <= target {
+			break
+		}
+		plan = append(plan, e.Version)
+	}
+	return plan, nil
+}
+
+// PlanRedo returns the single most recently applied version, unapplied then
+// reapplied, or an error if nothing has been applied yet.
+func PlanRedo(state State) (string, error) {
+	v := state.Latest()
+	if v == "" {
+		return "", fmt.Errorf("migrate: no applied migrations to redo")
+	}
+	return v, nil
+}
+
+func sortedEntries(entries []Entry) []Entry {
+	sorted := make([]Entry, len(entries))
+	copy(sorted, entries)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Version < sorted[j].Version })
+	return sorted
+}
+
+func containsVersion(entries []Entry, version string) bool {
+	for _, e := range entries {
+		if e.Version == version {
+			return true
+		}
+	}
+	return false
+}
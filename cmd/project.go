@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/pelletier/go-toml/v2"
+)
+
+const projectConfigPath = "dbexp.toml"
+
+// ProjectConfig is the root dbexp.toml project file. It records where
+// schemas live and the defaults init table should use for its generated
+// fields, so a project's conventions don't have to be repeated on every
+// command invocation.
+type ProjectConfig struct {
+	Name                 string                  `toml:"name"`
+	SchemaDir            string                  `toml:"schema_dir"`
+	DefaultUUIDVersion   string                  `toml:"default_uuid_version"`
+	DefaultTimestampType schema.DataType         `toml:"default_timestamp_type"`
+	DefaultTimePrecision int                     `toml:"default_time_precision,omitempty"`
+	Templates            map[string][]string     `toml:"templates,omitempty"`
+	Lint                 LintConfig              `toml:"lint,omitempty"`
+	Enums                []schema.EnumDefinition `toml:"enums,omitempty"`
+
+	// AutoMigrate makes add field, remove field, and rename field write a
+	// migration for every change, as if --migration had been passed.
+	AutoMigrate bool `toml:"auto_migrate,omitempty"`
+
+	// MigrationsDir is where those automatic migrations are written.
+	// Defaults to "migrations", matching migrate generate's own default.
+	MigrationsDir string `toml:"migrations_dir,omitempty"`
+}
+
+// builtinTemplates are the presets available to init table --template even
+// without a dbexp.toml, using the same "name:type[:required][:unique]"
+// field spec format as init table --field. A project's dbexp.toml can add
+// its own templates or override these by reusing the same name.
+var builtinTemplates = map[string][]string{
+	"audited": {"created_by:uuid", "updated_by:uuid"},
+}
+
+// templates is the preset name -> field specs lookup init table --template
+// resolves against. It starts as builtinTemplates and is extended (or
+// overridden, entry by entry) by loadProjectConfig when dbexp.toml sets
+// templates.
+var templates = copyTemplates(builtinTemplates)
+
+func copyTemplates(src map[string][]string) map[string][]string {
+	dst := make(map[string][]string, len(src))
+	for name, fields := range src {
+		dst[name] = fields
+	}
+	return dst
+}
+
+// defaultUUIDVersion is the uuid version init table uses for the id field
+// when --uuid-version isn't passed. It defaults to 4 but is overridden at
+// startup by loadProjectConfig when dbexp.toml sets default_uuid_version.
+var defaultUUIDVersion = 4
+
+// defaultTimePrecision is the fractional-second precision init table gives
+// its generated created_at/updated_at fields when neither --time-precision
+// nor --default-time-precision is passed. It defaults to 0 (unspecified,
+// the database's own default) but is overridden at startup by
+// loadProjectConfig when dbexp.toml sets default_time_precision.
+var defaultTimePrecision = 0
+
+// autoMigrate makes add field, remove field, and rename field write a
+// migration for every change without needing --migration. It defaults to
+// false but is overridden at startup by loadProjectConfig when dbexp.toml
+// sets auto_migrate.
+var autoMigrate = false
+
+// migrationsDir is where add field, remove field, and rename field write
+// an automatic migration, whether triggered by --migration or
+// auto_migrate. It defaults to "migrations", matching migrate generate's
+// own default, but is overridden at startup by loadProjectConfig when
+// dbexp.toml sets migrations_dir.
+var migrationsDir = "migrations"
+
+// ParseUUIDVersion parses s ("4", "v4", "7", or "v7") into a UUID version
+// number, rejecting anything else.
+func ParseUUIDVersion(s string) (int, error) {
+	switch s {
+	case "4", "v4":
+		return 4, nil
+	case "7", "v7":
+		return 7, nil
+	default:
+		return 0, fmt.Errorf("unsupported uuid version %q, expected 4 or 7", s)
+	}
+}
+
+// loadProjectConfig reads dbexp.toml from the current directory, if
+// present, and applies its schema_dir and default_uuid_version to
+// schema.Dir and defaultUUIDVersion. It's a no-op when no project file
+// exists, so standalone schema files keep working.
+func loadProjectConfig() (ProjectConfig, error) {
+	var cfg ProjectConfig
+	data, err := os.ReadFile(projectConfigPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("%s: %w", projectConfigPath, err)
+	}
+	if cfg.SchemaDir != "" {
+		schema.Dir = cfg.SchemaDir
+	}
+	if cfg.DefaultUUIDVersion != "" {
+		version, err := ParseUUIDVersion(cfg.DefaultUUIDVersion)
+		if err != nil {
+			return cfg, fmt.Errorf("%s: default_uuid_version: %w", projectConfigPath, err)
+		}
+		defaultUUIDVersion = version
+	}
+	if cfg.DefaultTimePrecision != 0 {
+		if cfg.DefaultTimePrecision < 0 || cfg.DefaultTimePrecision > 6 {
+			return cfg, fmt.Errorf("%s: default_time_precision must be between 0 and 6", projectConfigPath)
+		}
+		defaultTimePrecision = cfg.DefaultTimePrecision
+	}
+	for name, fields := range cfg.Templates {
+		templates[name] = fields
+	}
+	for name, enabled := range cfg.Lint.Rules {
+		lintRuleConfig[name] = enabled
+	}
+	for _, def := range cfg.Enums {
+		schema.RegisterEnum(def)
+	}
+	autoMigrate = cfg.AutoMigrate
+	if cfg.MigrationsDir != "" {
+		migrationsDir = cfg.MigrationsDir
+	}
+	return cfg, nil
+}
+
+// runInitProject scaffolds a dbexp.toml project file and its schema
+// directory in the current directory.
+func runInitProject() error {
+	name := CLI.Init.Project.Name
+	if name == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		name = filepath.Base(cwd)
+	}
+
+	cfg := ProjectConfig{
+		Name:                 name,
+		SchemaDir:            "schemas",
+		DefaultUUIDVersion:   "v4",
+		DefaultTimestampType: schema.DataTypeTimestampTZ,
+	}
+	data, err := toml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	if err := writeSchemaFile(projectConfigPath, data, CLI.Init.Project.Force); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(cfg.SchemaDir, 0o755); err != nil {
+		return err
+	}
+	statusf("Wrote %s", projectConfigPath)
+	fmt.Println("Created", cfg.SchemaDir+"/")
+	return nil
+}
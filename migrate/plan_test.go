@@ -0,0 +1,81 @@
+package migrate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanUp(t *testing.T) {
+	entries := []Entry{{Version: "v1", Table: "t"}, {Version: "v2", Table: "t"}, {Version: "v3", Table: "t"}}
+
+	cases := []struct {
+		name    string
+		state   State
+		target  string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "no target applies everything pending",
+			state:  State{},
+			target: "",
+			want:   []string{"v1", "v2", "v3"},
+		},
+		{
+			name:   "target already applied reports nothing to do",
+			state:  State{Applied: []string{"v1", "v2"}},
+			target: "v2",
+			want:   nil,
+		},
+		{
+			name:   "target ahead of applied stops at target",
+			state:  State{Applied: []string{"v1"}},
+			target: "v2",
+			want:   []string{"v2"},
+		},
+		{
+			name:    "unknown target errors",
+			state:   State{},
+			target:  "v9",
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := PlanUp(entries, c.state, c.target)
+			if c.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got plan %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Fatalf("PlanUp() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+// TestPlanUpStopsScanningAtTarget guards against a regression where PlanUp
+// kept scanning past an already-applied target and silently planned every
+// later unapplied version instead. This is the exact state
+// `migrate redo --dry-run` leaves behind (the down half never persists under
+// --dry-run, so by the time the up half runs, its target still looks
+// applied): entries v1..v3, applied = [v1, v2], target = "v2" used to plan
+// ["v3"] ("up v3") instead of reporting nothing to do.
+func TestPlanUpStopsScanningAtTarget(t *testing.T) {
+	entries := []Entry{{Version: "v1", Table: "t"}, {Version: "v2", Table: "t"}, {Version: "v3", Table: "t"}}
+	state := State{Applied: []string{"v1", "v2"}}
+
+	up, err := PlanUp(entries, state, "v2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(up) != 0 {
+		t.Fatalf("PlanUp() = %v, want an empty plan (v2 is already applied)", up)
+	}
+}
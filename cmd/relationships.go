@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// relationshipEdge is one foreign-key edge in the project's dependency
+// graph, collected from either a field's single-column References or a
+// table's multi-column ForeignKeys.
+type relationshipEdge struct {
+	FromTable   string   `json:"from_table"`
+	FromColumns []string `json:"from_columns"`
+	ToTable     string   `json:"to_table"`
+	ToColumns   []string `json:"to_columns"`
+	OnDelete    string   `json:"on_delete,omitempty"`
+	OnUpdate    string   `json:"on_update,omitempty"`
+}
+
+// String renders an edge as "from_table.column -> to_table.column", with
+// referential actions appended when set to anything other than the
+// implicit "no action".
+func (e relationshipEdge) String() string {
+	line := fmt.Sprintf("%s.%s -> %s.%s", e.FromTable, strings.Join(e.FromColumns, ","), e.ToTable, strings.Join(e.ToColumns, ","))
+	if e.OnDelete != "" && e.OnDelete != "no action" {
+		line += " on_delete=" + e.OnDelete
+	}
+	if e.OnUpdate != "" && e.OnUpdate != "no action" {
+		line += " on_update=" + e.OnUpdate
+	}
+	return line
+}
+
+// runListRelationships loads every schema in the project and collects
+// every field-level References and table-level ForeignKey into a single
+// list of edges, warning about any that point at a table or column that
+// doesn't exist rather than failing outright.
+func runListRelationships() error {
+	schemas, err := schema.AllSchemas()
+	if err != nil {
+		return err
+	}
+	known := make(map[string]schema.TableSchema, len(schemas))
+	for _, s := range schemas {
+		known[s.Name] = s
+	}
+
+	var edges []relationshipEdge
+	for _, s := range schemas {
+		names := make([]string, 0, len(s.Fields))
+		for name := range s.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			f := s.Fields[name]
+			if f.References == nil {
+				continue
+			}
+			target, ok := known[f.References.Table]
+			if !ok {
+				warnf("%s.%s: references unknown table %q", s.Name, name, f.References.Table)
+				continue
+			}
+			if _, ok := target.Fields[f.References.Field]; !ok {
+				warnf("%s.%s: references unknown field %q on table %q", s.Name, name, f.References.Field, f.References.Table)
+				continue
+			}
+			edges = append(edges, relationshipEdge{
+				FromTable:   s.Name,
+				FromColumns: []string{name},
+				ToTable:     f.References.Table,
+				ToColumns:   []string{f.References.Field},
+				OnDelete:    f.References.OnDelete,
+				OnUpdate:    f.References.OnUpdate,
+			})
+		}
+
+		for i, fk := range s.ForeignKeys {
+			target, ok := known[fk.RefTable]
+			if !ok {
+				warnf("%s: foreign key %d references unknown table %q", s.Name, i, fk.RefTable)
+				continue
+			}
+			dangling := false
+			for _, refName := range fk.RefColumns {
+				if _, ok := target.Fields[refName]; !ok {
+					warnf("%s: foreign key %d references unknown field %q on table %q", s.Name, i, refName, fk.RefTable)
+					dangling = true
+				}
+			}
+			if dangling {
+				continue
+			}
+			edges = append(edges, relationshipEdge{
+				FromTable:   s.Name,
+				FromColumns: fk.Columns,
+				ToTable:     fk.RefTable,
+				ToColumns:   fk.RefColumns,
+				OnDelete:    fk.OnDelete,
+				OnUpdate:    fk.OnUpdate,
+			})
+		}
+	}
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].FromTable != edges[j].FromTable {
+			return edges[i].FromTable < edges[j].FromTable
+		}
+		return strings.Join(edges[i].FromColumns, ",") < strings.Join(edges[j].FromColumns, ",")
+	})
+
+	if CLI.JSON {
+		if edges == nil {
+			edges = []relationshipEdge{}
+		}
+		return printJSON(edges)
+	}
+
+	if len(edges) == 0 {
+		fmt.Println("no relationships found")
+		return nil
+	}
+	for _, e := range edges {
+		fmt.Println(e)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package migrate
+
+// State tracks which migration versions have been applied to a database. It
+// is serialized to TOML alongside the migration files; it is not mirrored
+// into a metadata table in the target database.
+type State struct {
+	Applied []string `toml:"applied"`
+}
+
+// Contains reports whether version has already been applied.
+func (s State) Contains(version string) bool {
+	for _, v := range s.Applied {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply records version as applied, keeping Applied sorted.
+func (s *State) Apply(version string) {
+	if s.Contains(version) {
+		return
+	}
+	s.Applied = append(s.Applied, version)
+	sortVersions(s.Applied)
+}
+
+// Unapply removes version from the applied set.
+func (s *State) Unapply(version string) {
+	out := s.Applied[:0]
+	for _, v := range s.Applied {
+		if v != version {
+			out = append(out, v)
+		}
+	}
+	s.Applied = out
+}
+
+// Latest returns the most recently applied version, or "" if none.
+func (s State) Latest() string {
+	if len(s.Applied) == 0 {
+		return ""
+	}
+	return s.Applied[len(s.Applied)-1]
+}
+
+func sortVersions(versions []string) {
+	for i := 1; i < len(versions); i++ {
+		for j := i; j > 0 && versions[j-1] > versions[j]; j-- {
+			versions[j-1], versions[j] = versions[j], versions[j-1]
+		}
+	}
+}
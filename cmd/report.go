@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// junitTestSuite is the minimal JUnit XML shape CI tools understand: one
+// testsuite containing one testcase per schema file, with a failure child
+// for any file that didn't validate.
+type junitTestSuite struct {
+	XMLName  xml.Name        `xml:"testsuite"`
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// runReport validates every schema file independently via
+// schema.ValidateAllFiles and prints the result in the shape CLI.Report.Format
+// names, exiting non-zero if any file failed.
+func runReport() error {
+	reports, err := schema.ValidateAllFiles()
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range reports {
+		if !r.OK {
+			failed++
+		}
+	}
+
+	switch CLI.Report.Format {
+	case "json":
+		if reports == nil {
+			reports = []schema.FileReport{}
+		}
+		if err := printJSON(reports); err != nil {
+			return err
+		}
+	case "junit":
+		printJUnitReport(reports)
+	default:
+		printTextReport(reports)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d schema file(s) failed: %w", failed, len(reports), schema.ErrValidation)
+	}
+	return nil
+}
+
+func printTextReport(reports []schema.FileReport) {
+	for _, r := range reports {
+		if r.OK {
+			fmt.Printf("%s: ok\n", r.File)
+			continue
+		}
+		fmt.Printf("%s: FAIL\n", r.File)
+		for _, msg := range r.Errors {
+			fmt.Printf("  %s\n", msg)
+		}
+	}
+}
+
+func printJUnitReport(reports []schema.FileReport) {
+	suite := junitTestSuite{Name: "dbexp schema validation", Tests: len(reports)}
+	for _, r := range reports {
+		tc := junitTestCase{ClassName: "schema", Name: r.File}
+		if !r.OK {
+			suite.Failures++
+			tc.Failure = &junitFailure{
+				Message: strings.Join(r.Errors, "; "),
+				Text:    strings.Join(r.Errors, "\n"),
+			}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	data, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		warnf("report: couldn't render junit output: %v", err)
+		return
+	}
+	fmt.Println(xml.Header + string(data))
+}
@@ -0,0 +1,106 @@
+// Package project defines the dbexp.toml workspace manifest that ties
+// together a project's tables, migrations, and database connections, so
+// that a directory of TOML files can be treated as one coherent schema
+// rather than a pile of one-shot generator output.
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// ManifestFilename is the name of the project manifest file, always at the
+// root of a dbexp workspace.
+const ManifestFilename = "dbexp.toml"
+
+// Manifest is the root configuration of a dbexp workspace.
+type Manifest struct {
+	Name           string            `toml:"name"`
+	DefaultDialect string            `toml:"default_dialect"`
+	TablesDir      string            `toml:"tables_dir"`
+	MigrationsDir  string            `toml:"migrations_dir"`
+	Connections    map[string]string `toml:"connections"`
+	Tables         []string          `toml:"tables"`
+}
+
+// New returns a Manifest for a fresh project with the standard directory
+// layout.
+func New(name, defaultDialect string) Manifest {
+	return Manifest{
+		Name:           name,
+		DefaultDialect: defaultDialect,
+		TablesDir:      "tables",
+		MigrationsDir:  "migrations",
+		Connections:    map[string]string{},
+	}
+}
+
+// AddTable records name in the manifest's table index, if it isn't already
+// there.
+func (m *Manifest) AddTable(name string) {
+	for _, existing := range m.Tables {
+		if existing == name {
+			return
+		}
+	}
+	m.Tables = append(m.Tables, name)
+}
+
+// Load reads the manifest at dir/dbexp.toml.
+func Load(dir string) (Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFilename))
+	if err != nil {
+		return Manifest{}, err
+	}
+	var m Manifest
+	if err := toml.Unmarshal(data, &m); err != nil {
+		return Manifest{}, fmt.Errorf("parsing %s: %w", ManifestFilename, err)
+	}
+	return m, nil
+}
+
+// Save writes the manifest to dir/dbexp.toml.
+func Save(dir string, m Manifest) error {
+	data, err := toml.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", ManifestFilename, err)
+	}
+	return os.WriteFile(filepath.Join(dir, ManifestFilename), data, 0o644)
+}
+
+// Find walks upward from start looking for a dbexp.toml, the way a VCS root
+// is discovered, and returns the manifest together with the directory it was
+// found in.
+func Find(start string) (Manifest, string, error) {
+	dir, err := filepath.Abs(start)
+	if err != nil {
+		return Manifest{}, "", err
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ManifestFilename)); err == nil {
+			m, err := Load(dir)
+			return m, dir, err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return Manifest{}, "", fmt.Errorf("project: no %s found in %q or any parent directory", ManifestFilename, start)
+		}
+		dir = parent
+	}
+}
+
+// TablesPath returns the absolute path to the project's tables directory.
+func (m Manifest) TablesPath(projectDir string) string {
+	return filepath.Join(projectDir, m.TablesDir)
+}
+
+// MigrationsPath returns the absolute path to the project's migrations
+// directory.
+func (m Manifest) MigrationsPath(projectDir string) string {
+	return filepath.Join(projectDir, m.MigrationsDir)
+}
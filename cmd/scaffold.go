@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// joinTablePrimaryKey returns the name of s's sole primary key field, after
+// checking it exists and is a uuid, since that's what a join table's
+// foreign keys need to point at. s lacks a usable primary key (no field
+// named "id" and none declared, a composite key, or a non-uuid key) is
+// reported as an error naming s, not the caller's table arguments.
+func joinTablePrimaryKey(s schema.TableSchema) (string, error) {
+	pk := s.PrimaryKey
+	if len(pk) == 0 {
+		pk = []string{"id"}
+	}
+	if len(pk) != 1 {
+		return "", fmt.Errorf("table %q: scaffold join requires a single-field primary key, got %v", s.Name, pk)
+	}
+	f, ok := s.Fields[pk[0]]
+	if !ok {
+		return "", fmt.Errorf("table %q: lacks a primary key, %q does not exist", s.Name, pk[0])
+	}
+	if f.DataType != schema.DataTypeUUID {
+		return "", fmt.Errorf("table %q: primary key %q must be uuid, got %s", s.Name, pk[0], f.DataType)
+	}
+	return pk[0], nil
+}
+
+// runScaffoldJoin generates the schema for a many-to-many join table
+// between CLI.Scaffold.Join.TableA and TableB: a uuid foreign key to each
+// source table's primary key, a composite primary key over both, and the
+// standard created_at/updated_at timestamps, written the same way init
+// table writes a schema.
+func runScaffoldJoin() error {
+	a, err := schema.LoadSchemaByName(CLI.Scaffold.Join.TableA)
+	if err != nil {
+		return err
+	}
+	b, err := schema.LoadSchemaByName(CLI.Scaffold.Join.TableB)
+	if err != nil {
+		return err
+	}
+
+	aPK, err := joinTablePrimaryKey(a)
+	if err != nil {
+		return err
+	}
+	bPK, err := joinTablePrimaryKey(b)
+	if err != nil {
+		return err
+	}
+
+	name := CLI.Scaffold.Join.Name
+	if name == "" {
+		name = a.Name + "_" + b.Name
+	}
+	if err := schema.ValidateIdentifier(name); err != nil {
+		return fmt.Errorf("join table name: %w", err)
+	}
+
+	if a.Name == b.Name {
+		return fmt.Errorf("scaffold join: table %q can't join to itself, scaffold join only supports two distinct tables", a.Name)
+	}
+	aCol, bCol := a.Name+"_"+aPK, b.Name+"_"+bPK
+	if aCol == bCol {
+		return fmt.Errorf("scaffold join: %q and %q both produce column name %q, rename one source table's primary key first", a.Name, b.Name, aCol)
+	}
+	if aCol == "created_at" || aCol == "updated_at" || bCol == "created_at" || bCol == "updated_at" {
+		return fmt.Errorf("scaffold join: generated column name collides with the join table's own created_at/updated_at field, rename the colliding source table's primary key first")
+	}
+
+	var idGen IDGenerator = randomIDGenerator{}
+	tableId, err := idGen.NewUUID()
+	if err != nil {
+		return err
+	}
+	aColId, err := idGen.NewUUID()
+	if err != nil {
+		return err
+	}
+	bColId, err := idGen.NewUUID()
+	if err != nil {
+		return err
+	}
+	createdAtId, err := idGen.NewUUID()
+	if err != nil {
+		return err
+	}
+	updatedAtId, err := idGen.NewUUID()
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]schema.TableField{
+		aCol: {
+			Id:         aColId,
+			DataType:   schema.DataTypeUUID,
+			Required:   true,
+			References: &schema.References{Table: a.Name, Field: aPK, OnDelete: "cascade"},
+		},
+		bCol: {
+			Id:         bColId,
+			DataType:   schema.DataTypeUUID,
+			Required:   true,
+			References: &schema.References{Table: b.Name, Field: bPK, OnDelete: "cascade"},
+		},
+		"created_at": {
+			Id:        createdAtId,
+			DataType:  schema.DataTypeTimestampTZ,
+			Required:  true,
+			Automatic: true,
+			Immutable: true,
+		},
+		"updated_at": {
+			Id:        updatedAtId,
+			DataType:  schema.DataTypeTimestampTZ,
+			Required:  true,
+			Automatic: true,
+			OnUpdate:  true,
+		},
+	}
+
+	s := schema.TableSchema{
+		Id:         tableId,
+		Name:       name,
+		Fields:     fields,
+		Order:      []string{aCol, bCol, "created_at", "updated_at"},
+		Version:    1,
+		PrimaryKey: []string{aCol, bCol},
+		Comment:    fmt.Sprintf("Join table for the many-to-many relationship between %q and %q.", a.Name, b.Name),
+	}
+	if err := schema.Validate(s); err != nil {
+		return err
+	}
+
+	format := CLI.Scaffold.Join.Format
+	if format == "" {
+		format = CLI.DefaultFormat
+	}
+	if format == "" {
+		format = "toml"
+	}
+	cfg, err := schema.MarshalSchema(s, format)
+	if err != nil {
+		return err
+	}
+
+	out := CLI.Scaffold.Join.Output
+	if out == "" {
+		out = schema.Path(name)
+		if ext := schema.FormatExt(format); ext != ".toml" {
+			out = out[:len(out)-len(filepath.Ext(out))] + ext
+		}
+	}
+	if out == "-" || CLI.Scaffold.Join.DryRun {
+		fmt.Println(string(cfg))
+		if CLI.Scaffold.Join.DryRun {
+			fmt.Println("Would write", out)
+		}
+		return nil
+	}
+
+	if err := writeSchemaFile(out, cfg, CLI.Scaffold.Join.Force); err != nil {
+		return err
+	}
+	statusf("Wrote %s", out)
+	return nil
+}
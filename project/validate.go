@@ -0,0 +1,135 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// LoadTables reads every *.toml file in the project's tables directory as a
+// schema.TableSchema, keyed by table name.
+func LoadTables(m Manifest, projectDir string) (map[string]schema.TableSchema, error) {
+	dir := m.TablesPath(projectDir)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading tables directory: %w", err)
+	}
+
+	tables := make(map[string]schema.TableSchema, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".toml" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", e.Name(), err)
+		}
+
+		var t schema.TableSchema
+		if err := toml.Unmarshal(data, &t); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", e.Name(), err)
+		}
+
+		if existing, ok := tables[t.Name]; ok {
+			return nil, fmt.Errorf("duplicate table name %q (%s and a previous file, id %s)", t.Name, e.Name(), existing.Id)
+		}
+		tables[t.Name] = t
+	}
+
+	return tables, nil
+}
+
+// Validate checks tables as a whole: every table passes schema.Validate
+// against the full set (so foreign keys resolve), no two fields across the
+// whole project share a UUID, and the foreign key graph has no cycles.
+func Validate(tables map[string]schema.TableSchema) error {
+	seenIDs := make(map[string]string) // field id -> "table.field" it first appeared as
+
+	for tableName, t := range tables {
+		if err := schema.Validate(t, tables); err != nil {
+			return err
+		}
+
+		for fieldName, f := range t.Fields {
+			key := f.Id.String()
+			ref := tableName + "." + fieldName
+			if first, ok := seenIDs[key]; ok {
+				return fmt.Errorf("duplicate field id %s: used by both %s and %s", key, first, ref)
+			}
+			seenIDs[key] = ref
+		}
+	}
+
+	if cycle := findCycle(tables); cycle != "" {
+		return fmt.Errorf("foreign key cycle detected: %s", cycle)
+	}
+
+	return nil
+}
+
+// findCycle walks the foreign-key graph (table -> referenced table) and
+// returns a human-readable description of the first cycle found, or "" if
+// the graph is acyclic.
+func findCycle(tables map[string]schema.TableSchema) string {
+	const (
+		unvisited = 0
+		visiting  = 1
+		done      = 2
+	)
+	state := make(map[string]int, len(tables))
+	var path []string
+
+	var visit func(name string) string
+	visit = func(name string) string {
+		switch state[name] {
+		case visiting:
+			path = append(path, name)
+			return strings.Join(path, " -> ")
+		case done:
+			return ""
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+
+		t := tables[name]
+		for _, f := range t.Fields {
+			if f.ForeignKey == nil {
+				continue
+			}
+			// A table referencing its own rows (e.g. employees.manager_id ->
+			// employees.id) is an ordinary, valid pattern, not a cycle: it
+			// doesn't block CREATE TABLE generation the way a genuine
+			// multi-table cycle can for create-order.
+			if f.ForeignKey.Table == name {
+				continue
+			}
+			if _, ok := tables[f.ForeignKey.Table]; !ok {
+				continue
+			}
+			if cycle := visit(f.ForeignKey.Table); cycle != "" {
+				return cycle
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = done
+		return ""
+	}
+
+	for name := range tables {
+		if state[name] == unvisited {
+			if cycle := visit(name); cycle != "" {
+				return cycle
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+func runSeedExport() error {
+	s, err := schema.LoadSchemaByName(CLI.Seed.Export.Table)
+	if err != nil {
+		return err
+	}
+	stmts, err := s.SeedInserts()
+	if err != nil {
+		return err
+	}
+	for _, stmt := range stmts {
+		fmt.Println(stmt)
+	}
+	return nil
+}
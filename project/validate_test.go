@@ -0,0 +1,51 @@
+package project
+
+import (
+	"testing"
+
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/google/uuid"
+)
+
+func TestValidateAllowsSelfReferencingForeignKey(t *testing.T) {
+	employees := schema.TableSchema{
+		Id:   uuid.New(),
+		Name: "employees",
+		Fields: map[string]schema.TableField{
+			"id": {Id: uuid.New(), Type: schema.DataTypeUUID, PrimaryKey: true, Required: true},
+			"manager_id": {
+				Id:         uuid.New(),
+				Type:       schema.DataTypeUUID,
+				ForeignKey: &schema.ForeignKey{Table: "employees", Field: "id"},
+			},
+		},
+	}
+
+	if err := Validate(map[string]schema.TableSchema{"employees": employees}); err != nil {
+		t.Fatalf("Validate() rejected a self-referencing foreign key: %v", err)
+	}
+}
+
+func TestValidateDetectsMutualForeignKeyCycle(t *testing.T) {
+	a := schema.TableSchema{
+		Id:   uuid.New(),
+		Name: "a",
+		Fields: map[string]schema.TableField{
+			"id":   {Id: uuid.New(), Type: schema.DataTypeUUID, PrimaryKey: true, Required: true},
+			"b_id": {Id: uuid.New(), Type: schema.DataTypeUUID, ForeignKey: &schema.ForeignKey{Table: "b", Field: "id"}},
+		},
+	}
+	b := schema.TableSchema{
+		Id:   uuid.New(),
+		Name: "b",
+		Fields: map[string]schema.TableField{
+			"id":   {Id: uuid.New(), Type: schema.DataTypeUUID, PrimaryKey: true, Required: true},
+			"a_id": {Id: uuid.New(), Type: schema.DataTypeUUID, ForeignKey: &schema.ForeignKey{Table: "a", Field: "id"}},
+		},
+	}
+
+	err := Validate(map[string]schema.TableSchema{"a": a, "b": b})
+	if err == nil {
+		t.Fatal("Validate() should detect a genuine cross-table foreign key cycle")
+	}
+}
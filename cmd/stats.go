@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// statsReport is the JSON shape of `stats --json`.
+type statsReport struct {
+	TableCount       int            `json:"table_count"`
+	FieldCount       int            `json:"field_count"`
+	DataTypeUsage    map[string]int `json:"data_type_usage"`
+	TablesWithFK     int            `json:"tables_with_fk"`
+	TablesSoftDelete int            `json:"tables_soft_delete"`
+}
+
+// runStats loads every schema in schema.Dir and reports a project-wide
+// summary: table/field counts, DataType usage, and how many tables carry a
+// foreign key or follow the soft-delete convention. It's read-only, same as
+// runValidate and runLint, and never touches disk.
+func runStats() error {
+	tables, err := schema.AllSchemas()
+	if err != nil {
+		return err
+	}
+
+	report := statsReport{
+		TableCount:    len(tables),
+		DataTypeUsage: map[string]int{},
+	}
+	for _, s := range tables {
+		report.FieldCount += len(s.Fields)
+
+		hasFK := false
+		for _, f := range s.Fields {
+			report.DataTypeUsage[string(f.DataType)]++
+			if f.References != nil {
+				hasFK = true
+			}
+		}
+		if hasFK {
+			report.TablesWithFK++
+		}
+		if s.HasSoftDelete() {
+			report.TablesSoftDelete++
+		}
+	}
+
+	if CLI.JSON {
+		return printJSON(report)
+	}
+
+	fmt.Printf("tables:             %d\n", report.TableCount)
+	fmt.Printf("fields:             %d\n", report.FieldCount)
+	fmt.Printf("tables with fk:     %d\n", report.TablesWithFK)
+	fmt.Printf("tables soft-delete: %d\n", report.TablesSoftDelete)
+
+	if len(report.DataTypeUsage) > 0 {
+		fmt.Println("\ndata type usage:")
+		types := make([]string, 0, len(report.DataTypeUsage))
+		for t := range report.DataTypeUsage {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		for _, t := range types {
+			fmt.Printf("  %-16s %d\n", t, report.DataTypeUsage[t])
+		}
+	}
+
+	return nil
+}
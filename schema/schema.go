@@ -0,0 +1,230 @@
+// Package schema defines the TableSchema/TableField model used to describe a
+// table independently of any particular SQL dialect, and the TOML
+// serialization of that model that the rest of dbexp reads and writes.
+package schema
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// DataType identifies the kind of value a field holds. Some kinds carry
+// additional parameters on TableField (Length, Prec/Scale, Enum, Element) rather than encoding them into the DataType itself, so that the
+// type stays a simple, comparable string.
+type DataType string
+
+const (
+	DataTypeInt8      DataType = "int8"
+	DataTypeInt16     DataType = "int16"
+	DataTypeInt32     DataType = "int32"
+	DataTypeInt64     DataType = "int64"
+	DataTypeFloat32   DataType = "float32"
+	DataTypeFloat64   DataType = "float64"
+	DataTypeDecimal   DataType = "decimal"
+	DataTypeBool      DataType = "bool"
+	DataTypeText      DataType = "text"
+	DataTypeVarchar   DataType = "varchar"
+	DataTypeBytes     DataType = "bytes"
+	DataTypeJSON      DataType = "json"
+	DataTypeEnum      DataType = "enum"
+	DataTypeArray     DataType = "array"
+	DataTypeUUID      DataType = "uuid"
+	DataTypeTimestamp DataType = "timestamp"
+)
+
+// ReferentialAction is the behavior applied to a row when the row it
+// references (via a ForeignKey) is updated or deleted.
+type ReferentialAction string
+
+const (
+	ActionNoAction   ReferentialAction = "no_action"
+	ActionRestrict   ReferentialAction = "restrict"
+	ActionCascade    ReferentialAction = "cascade"
+	ActionSetNull    ReferentialAction = "set_null"
+	ActionSetDefault ReferentialAction = "set_default"
+)
+
+// ForeignKey describes the table+field a field references, by UUID when
+// known so that the link survives renames, falling back to name when the
+// referenced schema hasn't been seen yet.
+type ForeignKey struct {
+	Table    string            `toml:"table"`
+	TableId  uuid.UUID         `toml:"table_id,omitempty"`
+	Field    string            `toml:"field"`
+	FieldId  uuid.UUID         `toml:"field_id,omitempty"`
+	OnUpdate ReferentialAction `toml:"on_update,omitempty"`
+	OnDelete ReferentialAction `toml:"on_delete,omitempty"`
+}
+
+// TableField is a single column definition.
+type TableField struct {
+	Id   uuid.UUID `toml:"id"`
+	Type DataType  `toml:"type"`
+
+	// Parameters for types that need them.
+	Length  int       `toml:"length,omitempty"`    // varchar(n)
+	Prec    int       `toml:"precision,omitempty"` // decimal(p,s)
+	Scale   int       `toml:"scale,omitempty"`
+	Enum    []string  `toml:"enum,omitempty"`    // enum values
+	Element *DataType `toml:"element,omitempty"` // array<T> element type
+
+	Default string `toml:"default,omitempty"` // expression, e.g. "now()" or "0"
+	Check   string `toml:"check,omitempty"`   // predicate, e.g. "value >= 0"
+
+	// Faker names a generator in the seed package's registry (e.g. "email")
+	// to use instead of the type-based default when seeding fake data.
+	Faker string `toml:"faker,omitempty"`
+
+	Unique     bool        `toml:"unique"`
+	Required   bool        `toml:"required"`
+	Automatic  bool        `toml:"automatic"`
+	PrimaryKey bool        `toml:"primary_key,omitempty"`
+	Index      bool        `toml:"index,omitempty"`
+	ForeignKey *ForeignKey `toml:"foreign_key,omitempty"`
+}
+
+// TableSchema is the full description of a table, keyed by field name.
+type TableSchema struct {
+	Id     uuid.UUID             `toml:"id"`
+	Name   string                `toml:"name"`
+	Fields map[string]TableField `toml:"fields"`
+}
+
+// New returns a TableSchema for name with the standard id/created_at/updated_at
+// fields every table gets.
+func New(name string) TableSchema {
+	return TableSchema{
+		Id:   uuid.New(),
+		Name: name,
+		Fields: map[string]TableField{
+			"id": {
+				Id:         uuid.New(),
+				Type:       DataTypeUUID,
+				Unique:     true,
+				Required:   true,
+				Automatic:  true,
+				PrimaryKey: true,
+			},
+			"created_at": {
+				Id:        uuid.New(),
+				Type:      DataTypeTimestamp,
+				Required:  true,
+				Automatic: true,
+			},
+			"updated_at": {
+				Id:        uuid.New(),
+				Type:      DataTypeTimestamp,
+				Required:  true,
+				Automatic: true,
+			},
+		},
+	}
+}
+
+// Validate checks that s is internally consistent and, when known reports the
+// resolved set of tables in the project, that every foreign key target
+// exists. known may be nil when validating a table in isolation, in which
+// case foreign keys are only checked for well-formedness, not resolution.
+func Validate(s TableSchema, known map[string]TableSchema) error {
+	if s.Name == "" {
+		return fmt.Errorf("table: name is required")
+	}
+
+	pkCount := 0
+	for name, f := range s.Fields {
+		if err := validateField(name, f); err != nil {
+			return err
+		}
+		if f.PrimaryKey {
+			pkCount++
+		}
+		if fk := f.ForeignKey; fk != nil {
+			if (fk.Table == "" && fk.TableId == uuid.Nil) || (fk.Field == "" && fk.FieldId == uuid.Nil) {
+				return fmt.Errorf("field %q: foreign key must name a target table and field", name)
+			}
+			if known != nil {
+				if err := validateForeignKey(name, *fk, known); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	switch pkCount {
+	case 0:
+		return fmt.Errorf("table %q: exactly one primary key field is required, found none", s.Name)
+	case 1:
+		// ok
+	default:
+		return fmt.Errorf("table %q: exactly one primary key field is required, found %d", s.Name, pkCount)
+	}
+
+	return nil
+}
+
+func validateField(name string, f TableField) error {
+	switch f.Type {
+	case DataTypeVarchar:
+		if f.Length <= 0 {
+			return fmt.Errorf("field %q: varchar requires a positive length", name)
+		}
+	case DataTypeDecimal:
+		if f.Prec <= 0 {
+			return fmt.Errorf("field %q: decimal requires a positive precision", name)
+		}
+		if f.Scale < 0 || f.Scale > f.Prec {
+			return fmt.Errorf("field %q: decimal scale must be between 0 and precision", name)
+		}
+	case DataTypeEnum:
+		if len(f.Enum) == 0 {
+			return fmt.Errorf("field %q: enum requires at least one value", name)
+		}
+	case DataTypeArray:
+		if f.Element == nil {
+			return fmt.Errorf("field %q: array requires an element type", name)
+		}
+	}
+	return nil
+}
+
+func validateForeignKey(name string, fk ForeignKey, known map[string]TableSchema) error {
+	target, ok := resolveForeignTable(fk, known)
+	if !ok {
+		return fmt.Errorf("field %q: foreign key references unknown table %q", name, fk.Table)
+	}
+	if _, ok := resolveForeignField(fk, target); !ok {
+		return fmt.Errorf("field %q: foreign key references unknown field %q on table %q", name, fk.Field, fk.Table)
+	}
+	return nil
+}
+
+// resolveForeignTable looks fk's target table up by TableId when one is
+// set, so that a table rename doesn't break a foreign key that's otherwise
+// still valid, falling back to Table by name when it isn't.
+func resolveForeignTable(fk ForeignKey, known map[string]TableSchema) (TableSchema, bool) {
+	if fk.TableId != uuid.Nil {
+		for _, t := range known {
+			if t.Id == fk.TableId {
+				return t, true
+			}
+		}
+		return TableSchema{}, false
+	}
+	t, ok := known[fk.Table]
+	return t, ok
+}
+
+// resolveForeignField mirrors resolveForeignTable for fk's target field.
+func resolveForeignField(fk ForeignKey, target TableSchema) (TableField, bool) {
+	if fk.FieldId != uuid.Nil {
+		for _, f := range target.Fields {
+			if f.Id == fk.FieldId {
+				return f, true
+			}
+		}
+		return TableField{}, false
+	}
+	f, ok := target.Fields[fk.Field]
+	return f, ok
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/HoodieCollin/dbexp/schema"
+	"github.com/google/uuid"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// templateFileSkeleton is the shape of a --template-file skeleton: just the
+// fields a team wants every new table to start with, in the same TOML shape
+// as a real schema file's [fields] table, so a hand-written one can be
+// copied straight out of an existing table and trimmed down.
+type templateFileSkeleton struct {
+	Fields map[string]schema.TableField `toml:"fields"`
+	Order  []string                     `toml:"order,omitempty"`
+}
+
+// loadTemplateFile reads path and returns its fields in declaration order,
+// generating an id for any field that didn't specify one. A field without
+// a DataType is rejected outright, the same way a malformed --field spec
+// is.
+func loadTemplateFile(path string, idGen IDGenerator) ([]string, map[string]schema.TableField, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	var skeleton templateFileSkeleton
+	if err := toml.Unmarshal(data, &skeleton); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	order := skeleton.Order
+	if len(order) == 0 {
+		for name := range skeleton.Fields {
+			order = append(order, name)
+		}
+		sort.Strings(order)
+	}
+
+	fields := make(map[string]schema.TableField, len(skeleton.Fields))
+	for _, name := range order {
+		f, ok := skeleton.Fields[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("%s: order lists unknown field %q", path, name)
+		}
+		if err := schema.ValidateIdentifier(name); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if !f.DataType.Valid() {
+			return nil, nil, fmt.Errorf("%s: field %q: missing or unknown type %q", path, name, f.DataType)
+		}
+		if f.Id == uuid.Nil {
+			id, err := idGen.NewUUID()
+			if err != nil {
+				return nil, nil, err
+			}
+			f.Id = id
+		}
+		fields[name] = f
+	}
+	return order, fields, nil
+}
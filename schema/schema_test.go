@@ -0,0 +1,42 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestValidateForeignKeyByUUID(t *testing.T) {
+	target := TableSchema{
+		Id:   uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		Name: "users",
+		Fields: map[string]TableField{
+			"id": {Id: uuid.MustParse("22222222-2222-2222-2222-222222222222"), Type: DataTypeUUID, PrimaryKey: true, Required: true},
+		},
+	}
+
+	source := TableSchema{
+		Name: "posts",
+		Fields: map[string]TableField{
+			"id": {Id: uuid.MustParse("33333333-3333-3333-3333-333333333333"), Type: DataTypeUUID, PrimaryKey: true, Required: true},
+			"author_id": {
+				Id:   uuid.MustParse("44444444-4444-4444-4444-444444444444"),
+				Type: DataTypeUUID,
+				ForeignKey: &ForeignKey{
+					// Table/Field are stale (as if "accounts" were renamed to
+					// "users" and "user_id" to "id"), but the UUIDs still
+					// resolve.
+					Table:   "accounts",
+					TableId: target.Id,
+					Field:   "user_id",
+					FieldId: target.Fields["id"].Id,
+				},
+			},
+		},
+	}
+
+	known := map[string]TableSchema{"users": target, "posts": source}
+	if err := Validate(source, known); err != nil {
+		t.Fatalf("Validate() with stale names but valid UUIDs should pass, got: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// verifyResult reports the outcome of round-tripping one table through
+// Postgres DDL generation and ImportSQL.
+type verifyResult struct {
+	Table   string          `json:"table"`
+	OK      bool            `json:"ok"`
+	Changes []schema.Change `json:"changes,omitempty"`
+	Error   string          `json:"error,omitempty"`
+}
+
+func runVerify() error {
+	var tables []string
+	if CLI.Verify.All {
+		schemas, err := schema.AllSchemas()
+		if err != nil {
+			return err
+		}
+		for _, s := range schemas {
+			tables = append(tables, s.Name)
+		}
+	} else {
+		if CLI.Verify.Table == "" {
+			return fmt.Errorf("verify: pass a table name or --all")
+		}
+		tables = []string{CLI.Verify.Table}
+	}
+	sort.Strings(tables)
+
+	failed := 0
+	results := make([]verifyResult, 0, len(tables))
+	for _, table := range tables {
+		result := verifyResult{Table: table, OK: true}
+		changes, err := verifyRoundTrip(table)
+		if err != nil {
+			failed++
+			result.OK = false
+			result.Error = err.Error()
+			if !CLI.JSON {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		} else if len(changes) > 0 {
+			failed++
+			result.OK = false
+			result.Changes = changes
+			if !CLI.JSON {
+				for _, c := range changes {
+					fmt.Printf("%s: %s\n", table, c)
+				}
+			}
+		} else if !CLI.JSON {
+			fmt.Printf("%s: ok\n", table)
+		}
+		results = append(results, result)
+	}
+
+	if CLI.JSON {
+		if err := printJSON(results); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("verify: %d of %d table(s) lost information in DDL round-trip", failed, len(tables))
+	}
+	return nil
+}
+
+// verifyRoundTrip generates Postgres DDL for table, parses it back with
+// ImportSQL, and diffs the reconstructed schema against the original. The
+// returned changes are exactly what ImportSQL's deliberately lossy parser
+// (it warns and skips constraints it doesn't understand) failed to
+// reconstruct, which is itself a useful signal: a change here doesn't
+// necessarily mean the DDL is wrong, but it does mean that construct isn't
+// round-trippable from generated SQL alone.
+func verifyRoundTrip(table string) (schema.ChangeSet, error) {
+	s, err := schema.LoadSchemaByName(table)
+	if err != nil {
+		return nil, err
+	}
+	if s.IsView() {
+		return nil, nil
+	}
+
+	ddl, err := s.ToPostgresDDL()
+	if err != nil {
+		return nil, fmt.Errorf("table %q: %w", table, err)
+	}
+
+	reimported, err := schema.ImportSQL([]byte(ddl), func(string) {})
+	if err != nil {
+		return nil, fmt.Errorf("table %q: %w", table, err)
+	}
+	if len(reimported) != 1 {
+		return nil, fmt.Errorf("table %q: round-trip produced %d tables, expected 1", table, len(reimported))
+	}
+
+	return schema.DiffSchemas(s, reimported[0])
+}
@@ -0,0 +1,107 @@
+package seed
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/HoodieCollin/dbexp/dialect"
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// ToCSV renders rows as CSV with a header row of sorted field names.
+func ToCSV(table schema.TableSchema, rows []Row) (string, error) {
+	columns := seededColumns(table, rows)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(columns); err != nil {
+		return "", err
+	}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, col := range columns {
+			record[i] = fmt.Sprint(row[col])
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+// ToJSONL renders rows as newline-delimited JSON, one object per row.
+func ToJSONL(rows []Row) (string, error) {
+	var buf bytes.Buffer
+	for _, row := range rows {
+		data, err := json.Marshal(row)
+		if err != nil {
+			return "", err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return buf.String(), nil
+}
+
+// ToSQL renders rows as a batch of INSERT statements for d.
+func ToSQL(d dialect.Dialect, table schema.TableSchema, rows []Row) (string, error) {
+	columns := seededColumns(table, rows)
+
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = d.QuoteIdent(col)
+	}
+
+	var buf strings.Builder
+	for _, row := range rows {
+		values := make([]string, len(columns))
+		for i, col := range columns {
+			values[i] = sqlLiteral(row[col])
+		}
+		fmt.Fprintf(&buf, "INSERT INTO %s (%s) VALUES (%s);\n",
+			d.QuoteIdent(table.Name), strings.Join(quoted, ", "), strings.Join(values, ", "))
+	}
+	return buf.String(), nil
+}
+
+func sqlLiteral(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "NULL"
+	case bool:
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	case []byte:
+		return fmt.Sprintf("'%x'", v)
+	default:
+		return fmt.Sprintf("'%s'", strings.ReplaceAll(fmt.Sprint(v), "'", "''"))
+	}
+}
+
+// seededColumns returns the field names actually present in rows (i.e. every
+// non-Automatic field), sorted for deterministic output.
+func seededColumns(table schema.TableSchema, rows []Row) []string {
+	var columns []string
+	if len(rows) > 0 {
+		for col := range rows[0] {
+			columns = append(columns, col)
+		}
+	} else {
+		for name, f := range table.Fields {
+			if !f.Automatic {
+				columns = append(columns, name)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
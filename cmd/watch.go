@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+
+	"github.com/HoodieCollin/dbexp/schema"
+)
+
+// watchPollInterval is how often schema.Dir is polled for mtime changes.
+// There's no fsnotify-style dependency in this module, so polling trades
+// a little latency for not adding one.
+const watchPollInterval = 500 * time.Millisecond
+
+// watchDebounce is how long to wait after the most recent detected change
+// before re-running validation, so an editor's write-then-rename (or any
+// other burst of saves) only triggers one run.
+const watchDebounce = 300 * time.Millisecond
+
+// runWatch polls schema.Dir for changes to its schema files and
+// re-validates (optionally regenerating DDL) whenever one changes,
+// printing results to the terminal until interrupted with Ctrl-C.
+func runWatch() error {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	defer signal.Stop(sigCh)
+
+	mtimes, err := watchSnapshot()
+	if err != nil {
+		return err
+	}
+	runWatchCheck()
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-sigCh:
+			return nil
+		case <-ticker.C:
+			current, err := watchSnapshot()
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "watch:", err)
+				continue
+			}
+			if !watchSnapshotsEqual(mtimes, current) {
+				mtimes = current
+				debounce = time.After(watchDebounce)
+			}
+		case <-debounce:
+			debounce = nil
+			runWatchCheck()
+		}
+	}
+}
+
+// watchSnapshot maps every schema file in schema.Dir to its last-modified
+// time, for watchSnapshotsEqual to diff against the previous poll.
+func watchSnapshot() (map[string]time.Time, error) {
+	entries, err := os.ReadDir(schema.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	mtimes := make(map[string]time.Time, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !schema.IsSchemaFile(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		mtimes[entry.Name()] = info.ModTime()
+	}
+	return mtimes, nil
+}
+
+func watchSnapshotsEqual(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, t := range a {
+		if !b[name].Equal(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// runWatchCheck validates every schema in schema.Dir and, when
+// --dialect was given, regenerates and prints each table's DDL.
+func runWatchCheck() {
+	fmt.Printf("-- %s --\n", time.Now().Format("15:04:05"))
+
+	schemas, err := schema.AllSchemas()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "watch:", err)
+		return
+	}
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].Name < schemas[j].Name })
+
+	failed := 0
+	for _, s := range schemas {
+		loaded, err := schema.LoadSchemaByName(s.Name)
+		if err != nil {
+			failed++
+			fmt.Fprintln(os.Stderr, err)
+			continue
+		}
+		fmt.Printf("%s: ok\n", loaded.Name)
+
+		if CLI.Watch.Dialect == "" {
+			continue
+		}
+		var ddl string
+		switch CLI.Watch.Dialect {
+		case "postgres":
+			ddl, err = loaded.ToPostgresDDL()
+		case "sqlite":
+			ddl, err = loaded.ToSQLiteDDL()
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", loaded.Name, err)
+			continue
+		}
+		fmt.Print(ddl)
+	}
+	if failed > 0 {
+		fmt.Printf("%d of %d table(s) failed\n", failed, len(schemas))
+	}
+}
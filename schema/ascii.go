@@ -0,0 +1,73 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RenderASCII draws s as a bordered Unicode table for terminal inspection,
+// with columns for field name, type, required, unique, and default.
+// Automatic fields have their name marked with an asterisk.
+func (s TableSchema) RenderASCII() string {
+	headers := []string{"FIELD", "TYPE", "REQUIRED", "UNIQUE", "DEFAULT"}
+	names := s.FieldOrder()
+
+	rows := make([][]string, 0, len(names))
+	for _, name := range names {
+		f := s.Fields[name]
+		if f.Automatic {
+			name += "*"
+		}
+		rows = append(rows, []string{name, string(f.DataType), boolMark(f.Required), boolMark(f.Unique), f.Default})
+	}
+
+	widths := make([]int, len(headers))
+	for i, h := range headers {
+		widths[i] = len(h)
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	var b strings.Builder
+	writeBorder(&b, widths, "┌", "┬", "┐")
+	writeRow(&b, widths, headers)
+	writeBorder(&b, widths, "├", "┼", "┤")
+	for _, row := range rows {
+		writeRow(&b, widths, row)
+	}
+	writeBorder(&b, widths, "└", "┴", "┘")
+	return strings.TrimRight(b.String(), "\n")
+}
+
+func boolMark(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
+
+func writeBorder(b *strings.Builder, widths []int, left, mid, right string) {
+	b.WriteString(left)
+	for i, w := range widths {
+		b.WriteString(strings.Repeat("─", w+2))
+		if i < len(widths)-1 {
+			b.WriteString(mid)
+		}
+	}
+	b.WriteString(right)
+	b.WriteString("\n")
+}
+
+func writeRow(b *strings.Builder, widths []int, cells []string) {
+	b.WriteString("│")
+	for i, w := range widths {
+		fmt.Fprintf(b, " %-*s ", w, cells[i])
+		b.WriteString("│")
+	}
+	b.WriteString("\n")
+}
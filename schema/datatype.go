@@ -0,0 +1,267 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DataTypeSpec is everything a DataType needs to participate in DDL
+// generation, JSON Schema export, and validation, so a custom type like
+// "money" or "geo_point" can be added without forking the tool. Postgres,
+// SQLite, and JSONSchema are required; Validate is optional, for a type
+// with extra structural requirements (decimal's precision, enum's values).
+type DataTypeSpec struct {
+	Postgres   func(name string, f TableField) (string, error)
+	SQLite     func(f TableField) (string, error)
+	JSONSchema func(f TableField) (map[string]any, error)
+	Validate   func(name string, f TableField) error
+
+	// Numeric, Temporal, and Binary classify the type for the generic
+	// range/narrowing/formatting checks that key off DataType.IsNumeric,
+	// IsTemporal, and IsBinary.
+	Numeric  bool
+	Temporal bool
+	Binary   bool
+}
+
+// dataTypeRegistry is the name -> DataTypeSpec lookup every DataType
+// method and DDL/JSON Schema renderer consults. It's populated by the
+// init() below for built-in types and by RegisterDataType for custom
+// ones.
+var dataTypeRegistry = map[string]DataTypeSpec{}
+
+// RegisterDataType adds name to the registry, overriding any existing
+// spec under that name. Call it from an init() so the type is available
+// before any schema is loaded.
+func RegisterDataType(name string, spec DataTypeSpec) {
+	dataTypeRegistry[name] = spec
+}
+
+// RegisteredDataTypeNames returns every registered type name, sorted, for
+// ParseDataType's suggestion search and the form's type picker.
+func RegisteredDataTypeNames() []string {
+	names := make([]string, 0, len(dataTypeRegistry))
+	for name := range dataTypeRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	RegisterDataType(string(DataTypeUUID), DataTypeSpec{
+		Postgres: func(name string, f TableField) (string, error) { return "uuid", nil },
+		SQLite:   func(f TableField) (string, error) { return "TEXT", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) {
+			return map[string]any{"type": "string", "format": "uuid"}, nil
+		},
+	})
+	RegisterDataType(string(DataTypeTimestamp), DataTypeSpec{
+		Postgres: func(name string, f TableField) (string, error) { return postgresTemporalType("timestamp", f), nil },
+		SQLite:   func(f TableField) (string, error) { return "TEXT", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) {
+			return map[string]any{"type": "string", "format": "date-time"}, nil
+		},
+		Temporal: true,
+	})
+	RegisterDataType(string(DataTypeTimestampTZ), DataTypeSpec{
+		Postgres: func(name string, f TableField) (string, error) { return postgresTemporalType("timestamptz", f), nil },
+		SQLite:   func(f TableField) (string, error) { return "TEXT", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) {
+			return map[string]any{"type": "string", "format": "date-time"}, nil
+		},
+		Temporal: true,
+	})
+	RegisterDataType(string(DataTypeDate), DataTypeSpec{
+		Postgres: func(name string, f TableField) (string, error) { return "date", nil },
+		SQLite:   func(f TableField) (string, error) { return "TEXT", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) {
+			return map[string]any{"type": "string", "format": "date"}, nil
+		},
+		Temporal: true,
+	})
+	RegisterDataType(string(DataTypeTime), DataTypeSpec{
+		Postgres: func(name string, f TableField) (string, error) { return postgresTemporalType("time", f), nil },
+		SQLite:   func(f TableField) (string, error) { return "TEXT", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) {
+			return map[string]any{"type": "string", "format": "time"}, nil
+		},
+		Temporal: true,
+	})
+	RegisterDataType(string(DataTypeString), DataTypeSpec{
+		Postgres: func(name string, f TableField) (string, error) {
+			if f.MaxLength > 0 {
+				return fmt.Sprintf("varchar(%d)", f.MaxLength), nil
+			}
+			return "text", nil
+		},
+		SQLite: func(f TableField) (string, error) { return "TEXT", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) {
+			schema := map[string]any{"type": "string"}
+			if f.MaxLength > 0 {
+				schema["maxLength"] = f.MaxLength
+			}
+			if f.Pattern != "" {
+				schema["pattern"] = f.Pattern
+			}
+			return schema, nil
+		},
+	})
+	RegisterDataType(string(DataTypeInt32), DataTypeSpec{
+		Postgres: func(name string, f TableField) (string, error) { return "integer", nil },
+		SQLite:   func(f TableField) (string, error) { return "INTEGER", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) {
+			schema := map[string]any{"type": "integer"}
+			addNumericRange(schema, f)
+			return schema, nil
+		},
+		Numeric: true,
+	})
+	RegisterDataType(string(DataTypeInt64), DataTypeSpec{
+		Postgres: func(name string, f TableField) (string, error) { return "bigint", nil },
+		SQLite:   func(f TableField) (string, error) { return "INTEGER", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) {
+			schema := map[string]any{"type": "integer"}
+			addNumericRange(schema, f)
+			return schema, nil
+		},
+		Numeric: true,
+	})
+	RegisterDataType(string(DataTypeBool), DataTypeSpec{
+		Postgres:   func(name string, f TableField) (string, error) { return "boolean", nil },
+		SQLite:     func(f TableField) (string, error) { return "INTEGER", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) { return map[string]any{"type": "boolean"}, nil },
+	})
+	RegisterDataType(string(DataTypeFloat32), DataTypeSpec{
+		Postgres: func(name string, f TableField) (string, error) { return "real", nil },
+		SQLite:   func(f TableField) (string, error) { return "REAL", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) {
+			schema := map[string]any{"type": "number"}
+			addNumericRange(schema, f)
+			return schema, nil
+		},
+		Numeric: true,
+	})
+	RegisterDataType(string(DataTypeFloat64), DataTypeSpec{
+		Postgres: func(name string, f TableField) (string, error) { return "double precision", nil },
+		SQLite:   func(f TableField) (string, error) { return "REAL", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) {
+			schema := map[string]any{"type": "number"}
+			addNumericRange(schema, f)
+			return schema, nil
+		},
+		Numeric: true,
+	})
+	RegisterDataType(string(DataTypeDecimal), DataTypeSpec{
+		Postgres: func(name string, f TableField) (string, error) {
+			return fmt.Sprintf("numeric(%d,%d)", f.Precision, f.Scale), nil
+		},
+		SQLite: func(f TableField) (string, error) { return "NUMERIC", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) {
+			schema := map[string]any{"type": "number"}
+			addNumericRange(schema, f)
+			return schema, nil
+		},
+		Validate: func(name string, f TableField) error {
+			if f.Precision <= 0 {
+				return fmt.Errorf("field %q: decimal requires a positive precision", name)
+			}
+			if f.Scale < 0 || f.Scale > f.Precision {
+				return fmt.Errorf("field %q: decimal scale must be between 0 and precision", name)
+			}
+			return nil
+		},
+		Numeric: true,
+	})
+	RegisterDataType(string(DataTypeJSON), DataTypeSpec{
+		Postgres:   func(name string, f TableField) (string, error) { return "jsonb", nil },
+		SQLite:     func(f TableField) (string, error) { return "TEXT", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) { return map[string]any{}, nil },
+		Validate: func(name string, f TableField) error {
+			if f.Unique {
+				return fmt.Errorf("field %q: json fields cannot be marked unique", name)
+			}
+			return nil
+		},
+	})
+	RegisterDataType(string(DataTypeEnum), DataTypeSpec{
+		Postgres: func(name string, f TableField) (string, error) {
+			if f.EnumRef != "" {
+				return f.EnumRef, nil
+			}
+			values := make([]string, len(f.Values))
+			for i, v := range f.Values {
+				values[i] = "'" + strings.ReplaceAll(v, "'", "''") + "'"
+			}
+			return fmt.Sprintf("text check (%s in (%s))", name, strings.Join(values, ", ")), nil
+		},
+		SQLite: func(f TableField) (string, error) { return "TEXT", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) {
+			resolved, err := resolveEnumValues(f)
+			if err != nil {
+				return nil, err
+			}
+			values := make([]any, len(resolved))
+			for i, v := range resolved {
+				values[i] = v
+			}
+			return map[string]any{"type": "string", "enum": values}, nil
+		},
+		Validate: func(name string, f TableField) error {
+			if f.EnumRef != "" && len(f.Values) > 0 {
+				return fmt.Errorf("field %q: enum_ref and values are mutually exclusive", name)
+			}
+			values, err := resolveEnumValues(f)
+			if err != nil {
+				return fmt.Errorf("field %q: %w", name, err)
+			}
+			if len(values) == 0 {
+				return fmt.Errorf("field %q: enum requires at least one value", name)
+			}
+			return nil
+		},
+	})
+	RegisterDataType(string(DataTypeBytes), DataTypeSpec{
+		Postgres: func(name string, f TableField) (string, error) { return "bytea", nil },
+		SQLite:   func(f TableField) (string, error) { return "BLOB", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}, nil
+		},
+		Binary: true,
+	})
+	RegisterDataType(string(DataTypeArray), DataTypeSpec{
+		Postgres: func(name string, f TableField) (string, error) {
+			elem, err := postgresColumnType(name, TableField{DataType: f.ElementType})
+			if err != nil {
+				return "", err
+			}
+			return elem + "[]", nil
+		},
+		SQLite: func(f TableField) (string, error) { return "TEXT", nil },
+		JSONSchema: func(f TableField) (map[string]any, error) {
+			items, err := jsonSchemaType(TableField{DataType: f.ElementType})
+			if err != nil {
+				return nil, err
+			}
+			schema := map[string]any{"type": "array", "items": items}
+			if f.MaxItems > 0 {
+				schema["maxItems"] = f.MaxItems
+			}
+			return schema, nil
+		},
+		Validate: func(name string, f TableField) error {
+			if f.ElementType == "" {
+				return fmt.Errorf("field %q: array requires an element type", name)
+			}
+			if !f.ElementType.Valid() {
+				_, err := ParseDataType(string(f.ElementType))
+				return fmt.Errorf("field %q: element type: %w", name, err)
+			}
+			if f.ElementType == DataTypeArray {
+				return fmt.Errorf("field %q: array cannot nest array as its element type", name)
+			}
+			return nil
+		},
+	})
+}
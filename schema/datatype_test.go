@@ -0,0 +1,15 @@
+package schema
+
+import "testing"
+
+func TestPostgresColumnTypeEnumChecksOwnColumn(t *testing.T) {
+	f := TableField{DataType: DataTypeEnum, Values: []string{"open", "closed"}}
+	colType, err := postgresColumnType("status", f)
+	if err != nil {
+		t.Fatalf("postgresColumnType: %v", err)
+	}
+	want := "text check (status in ('open', 'closed'))"
+	if colType != want {
+		t.Fatalf("got %q, want %q", colType, want)
+	}
+}
@@ -0,0 +1,455 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// postgresColumnType renders f's Postgres column type. name is the
+// field's column name, needed by types like enum whose rendering
+// references its own column (e.g. an inline CHECK clause). auto_increment
+// overrides the declared type entirely, since serial/bigserial are
+// Postgres's de-facto auto-increment types; everything else is delegated
+// to f.DataType's registered DataTypeSpec, so a custom type's mapping
+// works here without this function knowing about it.
+func postgresColumnType(name string, f TableField) (string, error) {
+	if f.AutoIncrement {
+		if f.DataType == DataTypeInt64 {
+			return "bigserial", nil
+		}
+		return "serial", nil
+	}
+	spec, ok := dataTypeRegistry[string(f.DataType)]
+	if !ok || spec.Postgres == nil {
+		return "", fmt.Errorf("no postgres mapping for DataType %q", f.DataType)
+	}
+	return spec.Postgres(name, f)
+}
+
+// postgresTemporalType appends f.TimePrecision's fractional-second
+// precision clause to base ("timestamp", "timestamptz", or "time") when
+// set, e.g. "timestamp(3)". Zero leaves base unchanged, deferring to
+// Postgres's own default precision.
+func postgresTemporalType(base string, f TableField) string {
+	if f.TimePrecision == 0 {
+		return base
+	}
+	return fmt.Sprintf("%s(%d)", base, f.TimePrecision)
+}
+
+// ToPostgresDDL renders s as a deterministic Postgres CREATE TABLE
+// statement: columns in s.FieldOrder(), NOT NULL/UNIQUE/PRIMARY KEY
+// clauses, and DEFAULT expressions for the automatic id/timestamp fields.
+// The table is qualified with s.EffectiveNamespace(), preceded by a
+// CREATE SCHEMA IF NOT EXISTS for that namespace.
+func (s TableSchema) ToPostgresDDL() (string, error) {
+	return s.toPostgresDDL(nil)
+}
+
+// toPostgresDDL is ToPostgresDDL's implementation, with deferFK naming
+// fields (by name) whose FOREIGN KEY clause should be left out of the
+// CREATE TABLE body. ToPostgresDDLAll uses this to omit a constraint
+// that a foreign-key cycle forces it to add later via ALTER TABLE.
+func (s TableSchema) toPostgresDDL(deferFK map[string]bool) (string, error) {
+	if s.IsView() {
+		return s.toPostgresViewDDL()
+	}
+
+	names := s.FieldOrder()
+	namespace := s.EffectiveNamespace()
+	qualifiedName := namespace + "." + s.Name
+
+	pk := s.PrimaryKey
+	if len(pk) == 0 {
+		pk = []string{"id"}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE SCHEMA IF NOT EXISTS %s;\n\n", namespace)
+
+	enumRefs := map[string]bool{}
+	for _, name := range names {
+		if ref := s.Fields[name].EnumRef; ref != "" {
+			enumRefs[ref] = true
+		}
+	}
+	refNames := make([]string, 0, len(enumRefs))
+	for ref := range enumRefs {
+		refNames = append(refNames, ref)
+	}
+	sort.Strings(refNames)
+	for _, ref := range refNames {
+		def, ok := projectEnums[ref]
+		if !ok {
+			return "", fmt.Errorf("field references unknown enum %q", ref)
+		}
+		b.WriteString(postgresEnumTypeDDL(def))
+	}
+
+	fmt.Fprintf(&b, "CREATE TABLE %s (\n", qualifiedName)
+
+	var lines []string
+	for _, name := range names {
+		f := s.Fields[name]
+		colType, err := postgresColumnType(name, f)
+		if err != nil {
+			return "", fmt.Errorf("field %q: %w", name, err)
+		}
+
+		line := fmt.Sprintf("  %s %s", name, colType)
+		if f.Collation != "" {
+			line += fmt.Sprintf(" COLLATE %q", f.Collation)
+		}
+		if !f.Nullable() {
+			line += " NOT NULL"
+		}
+		if f.Unique {
+			line += " UNIQUE"
+			if f.UniqueNullsNotDistinct {
+				line += " NULLS NOT DISTINCT"
+			}
+		}
+		if f.IsGenerated() {
+			mode := "VIRTUAL"
+			if f.Stored {
+				mode = "STORED"
+			}
+			line += fmt.Sprintf(" GENERATED ALWAYS AS (%s) %s", f.Generated, mode)
+		} else if f.Default != "" {
+			line += " DEFAULT " + postgresDefaultLiteral(f)
+		} else if f.Automatic {
+			switch {
+			case f.DataType == DataTypeUUID && f.UUIDVersion == 7:
+				line += " DEFAULT uuidv7()"
+			case f.DataType == DataTypeUUID:
+				line += " DEFAULT gen_random_uuid()"
+			case f.DataType.IsTemporal():
+				line += " DEFAULT now()"
+			}
+		}
+		if f.Check != "" {
+			line += fmt.Sprintf(" CHECK (%s)", f.Check)
+		}
+		lines = append(lines, line)
+	}
+
+	lines = append(lines, fmt.Sprintf("  PRIMARY KEY (%s)", strings.Join(pk, ", ")))
+
+	for _, name := range names {
+		f := s.Fields[name]
+		if f.References == nil || deferFK[name] {
+			continue
+		}
+		lines = append(lines, "  "+postgresForeignKeyClause(namespace, name, f))
+	}
+
+	for _, fk := range s.ForeignKeys {
+		lines = append(lines, "  "+postgresForeignKeyGroupClause(namespace, fk))
+	}
+
+	for _, group := range s.Unique {
+		lines = append(lines, fmt.Sprintf("  UNIQUE (%s)", strings.Join(group, ", ")))
+	}
+
+	for _, check := range s.Checks {
+		lines = append(lines, fmt.Sprintf("  CONSTRAINT %s CHECK (%s)", check.Name, check.Expression))
+	}
+
+	b.WriteString(strings.Join(lines, ",\n"))
+	b.WriteString("\n)")
+	if p := s.Partition; p != nil {
+		fmt.Fprintf(&b, " PARTITION BY %s (%s)", strings.ToUpper(string(p.Strategy)), strings.Join(p.Fields, ", "))
+	}
+	if clause := postgresStorageClause(s.Options); clause != "" {
+		b.WriteString(" " + clause)
+	}
+	b.WriteString(";\n")
+
+	for _, idx := range s.Indexes {
+		b.WriteString("\n" + postgresCreateIndex(qualifiedName, idx))
+	}
+
+	for _, name := range names {
+		if s.Fields[name].OnUpdate {
+			fmt.Fprintf(&b, "\nCREATE OR REPLACE FUNCTION %s() RETURNS trigger AS $$\nBEGIN\n  NEW.%s = now();\n  RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql;\n\nCREATE TRIGGER %s\n  BEFORE UPDATE ON %s\n  FOR EACH ROW\n  EXECUTE FUNCTION %s();\n",
+				onUpdateFuncName(s.Name, name), name, onUpdateTriggerName(s.Name, name), qualifiedName, onUpdateFuncName(s.Name, name))
+		}
+		if s.Fields[name].Immutable {
+			fmt.Fprintf(&b, "\nCREATE OR REPLACE FUNCTION %s() RETURNS trigger AS $$\nBEGIN\n  IF NEW.%s IS DISTINCT FROM OLD.%s THEN\n    RAISE EXCEPTION '%s.%s is immutable';\n  END IF;\n  RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql;\n\nCREATE TRIGGER %s\n  BEFORE UPDATE ON %s\n  FOR EACH ROW\n  EXECUTE FUNCTION %s();\n",
+				immutableFuncName(s.Name, name), name, name, qualifiedName, name, immutableTriggerName(s.Name, name), qualifiedName, immutableFuncName(s.Name, name))
+		}
+	}
+
+	if s.Comment != "" {
+		fmt.Fprintf(&b, "\nCOMMENT ON TABLE %s IS %s;\n", qualifiedName, postgresStringLiteral(s.Comment))
+	}
+	for _, name := range names {
+		if comment := postgresColumnComment(s.Fields[name]); comment != "" {
+			fmt.Fprintf(&b, "COMMENT ON COLUMN %s.%s IS %s;\n", qualifiedName, name, postgresStringLiteral(comment))
+		}
+	}
+	return b.String(), nil
+}
+
+// toPostgresViewDDL renders s as a Postgres CREATE VIEW statement built
+// from s.Query, in place of toPostgresDDL's CREATE TABLE. s.Fields still
+// documents the view's output columns, but carries no DDL of its own — a
+// view's shape comes entirely from Query.
+func (s TableSchema) toPostgresViewDDL() (string, error) {
+	if s.Query == "" {
+		return "", fmt.Errorf("view %q: query is required", s.Name)
+	}
+	namespace := s.EffectiveNamespace()
+	qualifiedName := namespace + "." + s.Name
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE SCHEMA IF NOT EXISTS %s;\n\n", namespace)
+	fmt.Fprintf(&b, "CREATE VIEW %s AS\n%s;\n", qualifiedName, s.Query)
+
+	if s.Comment != "" {
+		fmt.Fprintf(&b, "\nCOMMENT ON VIEW %s IS %s;\n", qualifiedName, postgresStringLiteral(s.Comment))
+	}
+	for _, name := range s.FieldOrder() {
+		if comment := postgresColumnComment(s.Fields[name]); comment != "" {
+			fmt.Fprintf(&b, "COMMENT ON COLUMN %s.%s IS %s;\n", qualifiedName, name, postgresStringLiteral(comment))
+		}
+	}
+	return b.String(), nil
+}
+
+// postgresForeignKeyClause renders f's References as a "FOREIGN KEY
+// (field) REFERENCES ..." clause, with ON DELETE/ON UPDATE actions
+// appended when set to anything other than the implicit "no action".
+// namespace is used to qualify the target table when References.Namespace
+// is empty. Shared by ToPostgresDDL's inline rendering and
+// ToPostgresDDLAll's deferred ALTER TABLE statements, so both stay in
+// sync.
+func postgresForeignKeyClause(namespace, field string, f TableField) string {
+	refNamespace := f.References.Namespace
+	if refNamespace == "" {
+		refNamespace = namespace
+	}
+	line := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s.%s (%s)", field, refNamespace, f.References.Table, f.References.Field)
+	if f.References.OnDelete != "" && f.References.OnDelete != "no action" {
+		line += " ON DELETE " + strings.ToUpper(f.References.OnDelete)
+	}
+	if f.References.OnUpdate != "" && f.References.OnUpdate != "no action" {
+		line += " ON UPDATE " + strings.ToUpper(f.References.OnUpdate)
+	}
+	return line
+}
+
+// postgresForeignKeyGroupClause renders fk as a multi-column "FOREIGN KEY
+// (...) REFERENCES ...(...)" clause, for a composite key that a single
+// field's References can't express. Unlike postgresForeignKeyClause, these
+// are always rendered inline: ToPostgresDDLAll's cycle-breaking deferred
+// ALTER TABLE mechanism only tracks single-column references.
+func postgresForeignKeyGroupClause(namespace string, fk ForeignKey) string {
+	refNamespace := fk.Namespace
+	if refNamespace == "" {
+		refNamespace = namespace
+	}
+	line := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s.%s (%s)", strings.Join(fk.Columns, ", "), refNamespace, fk.RefTable, strings.Join(fk.RefColumns, ", "))
+	if fk.OnDelete != "" && fk.OnDelete != "no action" {
+		line += " ON DELETE " + strings.ToUpper(fk.OnDelete)
+	}
+	if fk.OnUpdate != "" && fk.OnUpdate != "no action" {
+		line += " ON UPDATE " + strings.ToUpper(fk.OnUpdate)
+	}
+	return line
+}
+
+// postgresStorageClause renders options as a trailing "WITH (...)
+// TABLESPACE ..." clause for CREATE TABLE. The "tablespace" key is
+// special-cased into its own TABLESPACE clause rather than folded into
+// WITH, since that's where Postgres's grammar puts it; every other key is
+// passed through as a storage parameter as-is, even one
+// UnknownTableOptions would flag, since DDL generation stays dialect-
+// permissive and leaves the warning to the caller.
+func postgresStorageClause(options map[string]string) string {
+	if len(options) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(options))
+	for k := range options {
+		if k != "tablespace" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var clause string
+	if len(keys) > 0 {
+		params := make([]string, len(keys))
+		for i, k := range keys {
+			params[i] = fmt.Sprintf("%s=%s", k, options[k])
+		}
+		clause = fmt.Sprintf("WITH (%s)", strings.Join(params, ", "))
+	}
+	if ts, ok := options["tablespace"]; ok {
+		if clause != "" {
+			clause += " "
+		}
+		clause += "TABLESPACE " + ts
+	}
+	return clause
+}
+
+// postgresConstraintName mirrors Postgres's own default foreign-key
+// constraint naming (<table>_<field>_fkey), so a deferred ALTER TABLE
+// statement names its constraint the same way an inline one would have.
+func postgresConstraintName(table, field string) string {
+	return fmt.Sprintf("%s_%s_fkey", table, field)
+}
+
+// ToPostgresDDLAll renders every table in tables as a single script safe
+// to run against an empty database: CREATE TABLE statements ordered by
+// foreign-key dependency (SortTablesByDependency), followed by an ALTER
+// TABLE ... ADD CONSTRAINT for any reference a dependency cycle forced
+// out of its CREATE TABLE body.
+func ToPostgresDDLAll(tables []TableSchema) (string, error) {
+	byName := make(map[string]TableSchema, len(tables))
+	for _, s := range tables {
+		byName[s.Name] = s
+	}
+
+	ordered, deferred := SortTablesByDependency(tables)
+	deferFK := make(map[string]map[string]bool, len(deferred))
+	for _, loc := range deferred {
+		table, field := splitTableField(loc)
+		if deferFK[table] == nil {
+			deferFK[table] = map[string]bool{}
+		}
+		deferFK[table][field] = true
+	}
+
+	var b strings.Builder
+	if len(deferred) > 0 {
+		fmt.Fprintf(&b, "-- foreign key cycle detected, deferring: %s\n\n", strings.Join(deferred, ", "))
+	}
+	for i, s := range ordered {
+		ddl, err := s.toPostgresDDL(deferFK[s.Name])
+		if err != nil {
+			return "", fmt.Errorf("table %q: %w", s.Name, err)
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(ddl)
+	}
+
+	if len(deferred) > 0 {
+		b.WriteString("\n")
+		for _, loc := range deferred {
+			table, field := splitTableField(loc)
+			s := byName[table]
+			f := s.Fields[field]
+			namespace := s.EffectiveNamespace()
+			fmt.Fprintf(&b, "ALTER TABLE %s.%s ADD CONSTRAINT %s %s;\n", namespace, table, postgresConstraintName(table, field), postgresForeignKeyClause(namespace, field, f))
+		}
+	}
+	return b.String(), nil
+}
+
+// splitTableField splits a "table.field" location string, as produced by
+// SortTablesByDependency's deferred list, back into its two parts.
+func splitTableField(loc string) (table, field string) {
+	i := strings.LastIndex(loc, ".")
+	if i < 0 {
+		return loc, ""
+	}
+	return loc[:i], loc[i+1:]
+}
+
+// postgresEnumTypeDDL renders def as a CREATE TYPE ... AS ENUM statement,
+// guarded by a DO block so generating DDL for a second table that
+// references the same named enum doesn't fail with "type already
+// exists" — Postgres has no CREATE TYPE IF NOT EXISTS.
+func postgresEnumTypeDDL(def EnumDefinition) string {
+	values := make([]string, len(def.Values))
+	for i, v := range def.Values {
+		values[i] = "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	}
+	return fmt.Sprintf("DO $$ BEGIN\n  CREATE TYPE %s AS ENUM (%s);\nEXCEPTION WHEN duplicate_object THEN null;\nEND $$;\n\n", def.Name, strings.Join(values, ", "))
+}
+
+// postgresColumnComment builds f's COMMENT ON COLUMN text, appending an
+// encrypted-at-rest note (and key reference, if given) to f.Comment so the
+// annotation survives into DDL even when there's no other comment.
+func postgresColumnComment(f TableField) string {
+	comment := f.Comment
+	if f.Encrypted {
+		note := "encrypted at rest"
+		if f.EncryptionKeyRef != "" {
+			note += " (key: " + f.EncryptionKeyRef + ")"
+		}
+		if comment != "" {
+			comment += " [" + note + "]"
+		} else {
+			comment = "[" + note + "]"
+		}
+	}
+	return comment
+}
+
+// postgresCreateIndex renders idx as a CREATE INDEX statement on table,
+// with a USING clause for a non-default method and a WHERE clause for a
+// partial index.
+func postgresCreateIndex(table string, idx Index) string {
+	var b strings.Builder
+	b.WriteString("CREATE ")
+	if idx.Unique {
+		b.WriteString("UNIQUE ")
+	}
+	fmt.Fprintf(&b, "INDEX %s ON %s", idx.Name, table)
+	if idx.Method != "" {
+		fmt.Fprintf(&b, " USING %s", idx.Method)
+	}
+	fmt.Fprintf(&b, " (%s)", strings.Join(idx.Fields, ", "))
+	if idx.Where != "" {
+		fmt.Fprintf(&b, " WHERE %s", idx.Where)
+	}
+	b.WriteString(";\n")
+	return b.String()
+}
+
+func onUpdateFuncName(table, field string) string {
+	return fmt.Sprintf("%s_%s_set_now", table, field)
+}
+
+func onUpdateTriggerName(table, field string) string {
+	return fmt.Sprintf("%s_%s_on_update", table, field)
+}
+
+func immutableFuncName(table, field string) string {
+	return fmt.Sprintf("%s_%s_immutable", table, field)
+}
+
+func immutableTriggerName(table, field string) string {
+	return fmt.Sprintf("%s_%s_on_update_immutable", table, field)
+}
+
+func postgresLiteral(f TableField) string {
+	switch f.DataType {
+	case DataTypeBool, DataTypeInt32, DataTypeInt64, DataTypeFloat32, DataTypeFloat64:
+		return f.Default
+	default:
+		return "'" + strings.ReplaceAll(f.Default, "'", "''") + "'"
+	}
+}
+
+// postgresDefaultLiteral renders f.Default for a DEFAULT clause: raw when
+// DefaultExpr marks it a function call like now(), quoted/escaped when
+// it's a literal value.
+func postgresDefaultLiteral(f TableField) string {
+	if f.DefaultExpr {
+		return f.Default
+	}
+	return postgresLiteral(f)
+}
+
+func postgresStringLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
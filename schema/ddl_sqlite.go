@@ -0,0 +1,204 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sqliteColumnType renders f's SQLite column type by delegating to
+// f.DataType's registered DataTypeSpec.
+func sqliteColumnType(f TableField) (string, error) {
+	spec, ok := dataTypeRegistry[string(f.DataType)]
+	if !ok || spec.SQLite == nil {
+		return "", fmt.Errorf("no sqlite mapping for DataType %q", f.DataType)
+	}
+	return spec.SQLite(f)
+}
+
+// ToSQLiteDDL renders s as a SQLite CREATE TABLE statement. SQLite lacks a
+// native gen_random_uuid(), so an automatic uuid default is left as a
+// comment for the application layer rather than emitted as an invalid
+// DEFAULT clause.
+func (s TableSchema) ToSQLiteDDL() (string, error) {
+	if s.IsView() {
+		return s.toSQLiteViewDDL()
+	}
+
+	names := s.FieldOrder()
+
+	pk := s.PrimaryKey
+	if len(pk) == 0 {
+		pk = []string{"id"}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE TABLE %s (\n", s.Name)
+
+	var lines []string
+	var comments []string
+	if s.Namespace != "" {
+		comments = append(comments, fmt.Sprintf("-- %s: sqlite has no schema/namespace concept, ignoring namespace %q", s.Name, s.Namespace))
+	}
+	if s.Partition != nil {
+		comments = append(comments, fmt.Sprintf("-- %s: sqlite has no native partitioning, ignoring partition by %s (%s)", s.Name, s.Partition.Strategy, strings.Join(s.Partition.Fields, ", ")))
+	}
+	if len(s.Options) > 0 {
+		keys := make([]string, 0, len(s.Options))
+		for k := range s.Options {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		comments = append(comments, fmt.Sprintf("-- %s: sqlite has no storage options, ignoring %s", s.Name, strings.Join(keys, ", ")))
+	}
+	for _, name := range names {
+		f := s.Fields[name]
+		colType, err := sqliteColumnType(f)
+		if err != nil {
+			return "", fmt.Errorf("field %q: %w", name, err)
+		}
+
+		line := fmt.Sprintf("  %s %s", name, colType)
+		if f.AutoIncrement {
+			line += " PRIMARY KEY AUTOINCREMENT"
+		}
+		if !f.Nullable() {
+			line += " NOT NULL"
+		}
+		if f.Unique {
+			line += " UNIQUE"
+		}
+		if f.IsGenerated() {
+			mode := "VIRTUAL"
+			if f.Stored {
+				mode = "STORED"
+			}
+			line += fmt.Sprintf(" GENERATED ALWAYS AS (%s) %s", f.Generated, mode)
+		} else if f.Default != "" {
+			line += " DEFAULT " + postgresDefaultLiteral(f)
+		} else if f.Automatic && f.DataType == DataTypeUUID {
+			comments = append(comments, fmt.Sprintf("-- %s: application must supply a uuid, sqlite has no gen_random_uuid()", name))
+		} else if f.Automatic && f.DataType.IsTemporal() {
+			line += " DEFAULT CURRENT_TIMESTAMP"
+		}
+		lines = append(lines, line)
+	}
+
+	autoIncrementPK := len(pk) == 1 && s.Fields[pk[0]].AutoIncrement
+	if !autoIncrementPK {
+		lines = append(lines, fmt.Sprintf("  PRIMARY KEY (%s)", strings.Join(pk, ", ")))
+	}
+
+	for _, group := range s.Unique {
+		lines = append(lines, fmt.Sprintf("  UNIQUE (%s)", strings.Join(group, ", ")))
+	}
+
+	for _, fk := range s.ForeignKeys {
+		comments = append(comments, fmt.Sprintf("-- %s: foreign key (%s) references %s (%s) not emitted, see ToSQLiteDDLAll's doc comment", s.Name, strings.Join(fk.Columns, ", "), fk.RefTable, strings.Join(fk.RefColumns, ", ")))
+	}
+
+	for _, name := range names {
+		if s.Fields[name].OnUpdate {
+			comments = append(comments, fmt.Sprintf("-- %s: application must update this on every write, sqlite triggers aren't generated here", name))
+		}
+		if s.Fields[name].TimePrecision != 0 {
+			comments = append(comments, fmt.Sprintf("-- %s: sqlite stores this as TEXT, ignoring time_precision %d", name, s.Fields[name].TimePrecision))
+		}
+		if s.Fields[name].UniqueNullsNotDistinct {
+			comments = append(comments, fmt.Sprintf("-- %s: sqlite has no UNIQUE NULLS NOT DISTINCT, multiple NULLs are still treated as distinct", name))
+		}
+		if s.Fields[name].Immutable {
+			comments = append(comments, fmt.Sprintf("-- %s: application must not update this column, sqlite triggers aren't generated here", name))
+		}
+		if s.Fields[name].Collation != "" {
+			comments = append(comments, fmt.Sprintf("-- %s: sqlite only supports BINARY/NOCASE/RTRIM collations, ignoring collation %q", name, s.Fields[name].Collation))
+		}
+	}
+
+	if s.Comment != "" {
+		comments = append(comments, fmt.Sprintf("-- %s: %s", s.Name, s.Comment))
+	}
+	for _, name := range names {
+		if comment := s.Fields[name].Comment; comment != "" {
+			comments = append(comments, fmt.Sprintf("-- %s.%s: %s", s.Name, name, comment))
+		}
+		if f := s.Fields[name]; f.Encrypted {
+			note := fmt.Sprintf("-- %s.%s: encrypted at rest", s.Name, name)
+			if f.EncryptionKeyRef != "" {
+				note += fmt.Sprintf(" (key: %s)", f.EncryptionKeyRef)
+			}
+			comments = append(comments, note)
+		}
+	}
+
+	for _, idx := range s.Indexes {
+		if idx.Method != "" {
+			comments = append(comments, fmt.Sprintf("-- %s: sqlite has no %s index method, falling back to its default b-tree index", idx.Name, idx.Method))
+		}
+	}
+
+	for _, c := range comments {
+		b.WriteString(c + "\n")
+	}
+	b.WriteString(strings.Join(lines, ",\n"))
+	b.WriteString("\n);\n")
+
+	for _, idx := range s.Indexes {
+		b.WriteString("\n" + sqliteCreateIndex(s.Name, idx))
+	}
+	return b.String(), nil
+}
+
+// toSQLiteViewDDL renders s as a SQLite CREATE VIEW statement built from
+// s.Query, in place of ToSQLiteDDL's CREATE TABLE.
+func (s TableSchema) toSQLiteViewDDL() (string, error) {
+	if s.Query == "" {
+		return "", fmt.Errorf("view %q: query is required", s.Name)
+	}
+	var b strings.Builder
+	if s.Comment != "" {
+		fmt.Fprintf(&b, "-- %s: %s\n", s.Name, s.Comment)
+	}
+	fmt.Fprintf(&b, "CREATE VIEW %s AS\n%s;\n", s.Name, s.Query)
+	return b.String(), nil
+}
+
+// ToSQLiteDDLAll renders every table in tables as a single script, ordered
+// by foreign-key dependency (SortTablesByDependency) for consistency with
+// ToPostgresDDLAll, though SQLite's CREATE TABLE never emits an inline
+// FOREIGN KEY clause (see ToSQLiteDDL), so no cycle can force a statement
+// out of order here the way it can for Postgres.
+func ToSQLiteDDLAll(tables []TableSchema) (string, error) {
+	ordered, _ := SortTablesByDependency(tables)
+
+	var b strings.Builder
+	for i, s := range ordered {
+		ddl, err := s.ToSQLiteDDL()
+		if err != nil {
+			return "", fmt.Errorf("table %q: %w", s.Name, err)
+		}
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(ddl)
+	}
+	return b.String(), nil
+}
+
+// sqliteCreateIndex renders idx as a CREATE INDEX statement on table. SQLite
+// has a single index implementation, so idx.Method is ignored (a comment
+// noting that is added by the caller); a WHERE clause is still supported
+// for partial indexes.
+func sqliteCreateIndex(table string, idx Index) string {
+	var b strings.Builder
+	b.WriteString("CREATE ")
+	if idx.Unique {
+		b.WriteString("UNIQUE ")
+	}
+	fmt.Fprintf(&b, "INDEX %s ON %s (%s)", idx.Name, table, strings.Join(idx.Fields, ", "))
+	if idx.Where != "" {
+		fmt.Fprintf(&b, " WHERE %s", idx.Where)
+	}
+	b.WriteString(";\n")
+	return b.String()
+}
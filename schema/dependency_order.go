@@ -0,0 +1,182 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SortTablesByDependency orders tables so a table referenced by another
+// (via a field's References or the table's ForeignKeys) always comes
+// before the table that references it, so DDL generated from the result
+// applies cleanly against an empty database. A view is conservatively
+// ordered after every
+// regular table, since its Query isn't parsed for what it actually
+// selects from; a view selecting from another view isn't covered by this
+// and falls back to alphabetical order. Among tables with no ordering
+// constraint
+// between them, the one with the alphabetically earlier name comes
+// first. A self-reference never forces reordering, since a FOREIGN KEY
+// can reference the table currently being created.
+//
+// When the References form a genuine cycle across two or more tables, no
+// ordering can honor every edge in it. One edge per cycle is broken by
+// dropping it from the dependency graph before ordering, and every field
+// that produced that broken edge (a table can have more than one field
+// referencing the same dependency) is returned in deferred as a
+// "table.field" string, naming the constraint a caller should add after
+// every table exists (e.g. via ALTER TABLE ... ADD CONSTRAINT) instead of
+// inline.
+func SortTablesByDependency(tables []TableSchema) (ordered []TableSchema, deferred []string) {
+	byName := make(map[string]TableSchema, len(tables))
+	for _, s := range tables {
+		byName[s.Name] = s
+	}
+
+	deps := map[string][]string{}
+	depFields := map[string]map[string][]string{}
+	for _, s := range tables {
+		// A view's dependencies live in its Query, which isn't parsed here,
+		// so conservatively depend on every regular table, since its Query
+		// could select from any of them. This doesn't cover a view
+		// selecting from another view: ordering two such views correctly
+		// would need to parse Query, which is out of scope here, so that
+		// case falls back to alphabetical order like any other tie.
+		if s.IsView() {
+			for _, other := range tables {
+				if other.Name != s.Name && !other.IsView() {
+					deps[s.Name] = append(deps[s.Name], other.Name)
+				}
+			}
+			sort.Strings(deps[s.Name])
+			continue
+		}
+		seen := map[string]bool{}
+		for _, name := range s.FieldOrder() {
+			f := s.Fields[name]
+			if f.References == nil || f.References.Table == s.Name {
+				continue
+			}
+			dep := f.References.Table
+			if _, ok := byName[dep]; !ok {
+				continue
+			}
+			if !seen[dep] {
+				deps[s.Name] = append(deps[s.Name], dep)
+				seen[dep] = true
+			}
+			if depFields[s.Name] == nil {
+				depFields[s.Name] = map[string][]string{}
+			}
+			depFields[s.Name][dep] = append(depFields[s.Name][dep], name)
+		}
+		for _, fk := range s.ForeignKeys {
+			if fk.RefTable == s.Name {
+				continue
+			}
+			dep := fk.RefTable
+			if _, ok := byName[dep]; !ok {
+				continue
+			}
+			if !seen[dep] {
+				deps[s.Name] = append(deps[s.Name], dep)
+				seen[dep] = true
+			}
+			if depFields[s.Name] == nil {
+				depFields[s.Name] = map[string][]string{}
+			}
+			depFields[s.Name][dep] = append(depFields[s.Name][dep], fk.Columns...)
+		}
+		sort.Strings(deps[s.Name])
+	}
+
+	// Phase 1: DFS over the full graph to find one back edge per cycle,
+	// the minimal set of edges that, once dropped, leaves a DAG.
+	const (
+		white = 0
+		gray  = 1
+		black = 2
+	)
+	state := map[string]int{}
+	type edge struct{ table, dep string }
+	var backEdges []edge
+
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = gray
+		for _, dep := range deps[name] {
+			switch state[dep] {
+			case white:
+				visit(dep)
+			case gray:
+				backEdges = append(backEdges, edge{table: name, dep: dep})
+			}
+		}
+		state[name] = black
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if state[name] == white {
+			visit(name)
+		}
+	}
+
+	broken := map[edge]bool{}
+	for _, e := range backEdges {
+		broken[e] = true
+		for _, field := range depFields[e.table][e.dep] {
+			deferred = append(deferred, fmt.Sprintf("%s.%s", e.table, field))
+		}
+	}
+	sort.Strings(deferred)
+
+	// Phase 2: Kahn's algorithm over the now-acyclic graph, always
+	// picking the alphabetically earliest ready table, so tables with no
+	// ordering constraint between them still come out in a fixed order.
+	inDegree := map[string]int{}
+	dependents := map[string][]string{}
+	for _, name := range names {
+		for _, dep := range deps[name] {
+			if broken[edge{table: name, dep: dep}] {
+				continue
+			}
+			inDegree[name]++
+			dependents[dep] = append(dependents[dep], name)
+		}
+	}
+	for _, deps := range dependents {
+		sort.Strings(deps)
+	}
+
+	ready := make([]string, 0, len(names))
+	for _, name := range names {
+		if inDegree[name] == 0 {
+			ready = append(ready, name)
+		}
+	}
+	sort.Strings(ready)
+
+	var order []string
+	for len(ready) > 0 {
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, name)
+		for _, dependent := range dependents[name] {
+			inDegree[dependent]--
+			if inDegree[dependent] == 0 {
+				ready = append(ready, dependent)
+				sort.Strings(ready)
+			}
+		}
+	}
+
+	ordered = make([]TableSchema, len(order))
+	for i, name := range order {
+		ordered[i] = byName[name]
+	}
+	return ordered, deferred
+}
@@ -0,0 +1,93 @@
+package schema
+
+import "testing"
+
+func tbl(name string, fields map[string]TableField, fks []ForeignKey) TableSchema {
+	return TableSchema{Name: name, Fields: fields, ForeignKeys: fks}
+}
+
+func TestSortTablesByDependency(t *testing.T) {
+	cases := []struct {
+		name   string
+		tables []TableSchema
+		want   []string
+	}{
+		{
+			name: "single column reference orders referenced table first",
+			tables: []TableSchema{
+				tbl("posts", map[string]TableField{
+					"id":      {DataType: DataTypeUUID, Required: true},
+					"user_id": {DataType: DataTypeUUID, References: &References{Table: "users", Field: "id"}},
+				}, nil),
+				tbl("users", map[string]TableField{
+					"id": {DataType: DataTypeUUID, Required: true},
+				}, nil),
+			},
+			want: []string{"users", "posts"},
+		},
+		{
+			name: "composite foreign key orders referenced table first",
+			tables: []TableSchema{
+				tbl("a", map[string]TableField{
+					"id":     {DataType: DataTypeUUID, Required: true},
+					"b_code": {DataType: DataTypeString},
+				}, []ForeignKey{{Columns: []string{"b_code"}, RefTable: "b", RefColumns: []string{"code"}}}),
+				tbl("b", map[string]TableField{
+					"code": {DataType: DataTypeString, Required: true},
+				}, nil),
+			},
+			want: []string{"b", "a"},
+		},
+		{
+			name: "no dependency falls back to alphabetical order",
+			tables: []TableSchema{
+				tbl("zeta", map[string]TableField{"id": {DataType: DataTypeUUID}}, nil),
+				tbl("alpha", map[string]TableField{"id": {DataType: DataTypeUUID}}, nil),
+			},
+			want: []string{"alpha", "zeta"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ordered, deferred := SortTablesByDependency(c.tables)
+			if len(deferred) != 0 {
+				t.Fatalf("unexpected deferred constraints: %v", deferred)
+			}
+			got := make([]string, len(ordered))
+			for i, s := range ordered {
+				got[i] = s.Name
+			}
+			if len(got) != len(c.want) {
+				t.Fatalf("got %v, want %v", got, c.want)
+			}
+			for i := range got {
+				if got[i] != c.want[i] {
+					t.Fatalf("got %v, want %v", got, c.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSortTablesByDependencyBreaksCycles(t *testing.T) {
+	tables := []TableSchema{
+		tbl("a", map[string]TableField{
+			"id":     {DataType: DataTypeUUID, Required: true},
+			"b_code": {DataType: DataTypeString},
+		}, []ForeignKey{{Columns: []string{"b_code"}, RefTable: "b", RefColumns: []string{"code"}}}),
+		tbl("b", map[string]TableField{
+			"id":   {DataType: DataTypeUUID, Required: true},
+			"a_id": {DataType: DataTypeUUID, References: &References{Table: "a", Field: "id"}},
+			"code": {DataType: DataTypeString, Required: true},
+		}, nil),
+	}
+
+	ordered, deferred := SortTablesByDependency(tables)
+	if len(ordered) != 2 {
+		t.Fatalf("expected both tables in the order, got %d", len(ordered))
+	}
+	if len(deferred) == 0 {
+		t.Fatalf("expected a cycle to force at least one deferred constraint")
+	}
+}
@@ -0,0 +1,589 @@
+package schema
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ChangeKind identifies the shape of a single structural difference
+// between two versions of a TableSchema.
+type ChangeKind string
+
+const (
+	ChangeAddField          ChangeKind = "add_field"
+	ChangeRemoveField       ChangeKind = "remove_field"
+	ChangeTypeChanged       ChangeKind = "type_changed"
+	ChangeConstraintChanged ChangeKind = "constraint_changed"
+	ChangeViewChanged       ChangeKind = "view_changed"
+)
+
+// Change describes one structural difference found by DiffSchemas, with
+// enough detail to render as a human summary or as ALTER TABLE SQL.
+type Change struct {
+	Kind        ChangeKind
+	Table       string
+	Field       string
+	Detail      string
+	Destructive bool
+
+	// OldField and NewField carry the full field definitions involved,
+	// when present, so SQL generation doesn't have to re-derive them
+	// from Detail's free-form text.
+	OldField *TableField
+	NewField *TableField
+
+	// OldQuery and NewQuery carry a view's SELECT text for a
+	// ChangeViewChanged, so SQL generation doesn't have to re-derive it
+	// from Detail's free-form text.
+	OldQuery string
+	NewQuery string
+
+	// OldSchema and NewSchema carry the full table/view definitions, so SQL
+	// generation can qualify names with EffectiveNamespace() instead of an
+	// unqualified name that resolves against search_path, and, for a
+	// ChangeViewChanged where Kind itself flips between table and view,
+	// render a complete CREATE TABLE or CREATE VIEW rather than just the
+	// query text.
+	OldSchema *TableSchema
+	NewSchema *TableSchema
+}
+
+// ChangeSet is a list of Changes from a single DiffSchemas call, with
+// methods to render them as migration SQL.
+type ChangeSet []Change
+
+// String renders a Change as a one-line human summary.
+func (c Change) String() string {
+	marker := ""
+	if c.Destructive {
+		marker = " [destructive]"
+	}
+	return fmt.Sprintf("%s %s: %s%s", c.Kind, c.Field, c.Detail, marker)
+}
+
+// DiffSchemas compares old against new and returns the structural changes
+// between them: fields added or removed, type changes, and constraint
+// changes (required/unique/default). Type narrowing and dropping a
+// required column are flagged Destructive so callers can gate on them
+// before applying a migration.
+func DiffSchemas(old, newer TableSchema) (ChangeSet, error) {
+	var changes ChangeSet
+	table := newer.Name
+	if table == "" {
+		table = old.Name
+	}
+
+	kindChanged := old.EffectiveKind() != newer.EffectiveKind()
+	if kindChanged || old.Query != newer.Query {
+		change := Change{
+			Kind:        ChangeViewChanged,
+			Table:       table,
+			Detail:      fmt.Sprintf("kind %s->%s, query %q->%q", old.EffectiveKind(), newer.EffectiveKind(), old.Query, newer.Query),
+			Destructive: kindChanged,
+			OldQuery:    old.Query,
+			NewQuery:    newer.Query,
+			// OldSchema and NewSchema carry the full definitions so SQL
+			// generation can qualify names with EffectiveNamespace() and,
+			// when kindChanged, render a complete CREATE TABLE or CREATE
+			// VIEW rather than just the query text.
+			OldSchema: &old,
+			NewSchema: &newer,
+		}
+		changes = append(changes, change)
+	}
+
+	// A view's Fields only document its output columns; its actual shape
+	// comes from Query, which ChangeViewChanged above already covers. Field-
+	// level changes here would render as ALTER TABLE ADD/DROP COLUMN against
+	// a CREATE VIEW target, which isn't valid SQL.
+	if old.IsView() || newer.IsView() {
+		return changes, nil
+	}
+
+	names := map[string]bool{}
+	for name := range old.Fields {
+		names[name] = true
+	}
+	for name := range newer.Fields {
+		names[name] = true
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		oldField, hadOld := old.Fields[name]
+		newField, hasNew := newer.Fields[name]
+
+		switch {
+		case !hadOld && hasNew:
+			changes = append(changes, Change{
+				Kind:      ChangeAddField,
+				Table:     table,
+				Field:     name,
+				Detail:    fmt.Sprintf("added as %s", newField.DataType),
+				NewField:  &newField,
+				OldSchema: &old,
+				NewSchema: &newer,
+			})
+		case hadOld && !hasNew:
+			changes = append(changes, Change{
+				Kind:        ChangeRemoveField,
+				Table:       table,
+				Field:       name,
+				Detail:      fmt.Sprintf("removed (was %s)", oldField.DataType),
+				Destructive: true,
+				OldField:    &oldField,
+				OldSchema:   &old,
+				NewSchema:   &newer,
+			})
+		default:
+			if detail, destructive, changed := fieldTypeDiff(oldField, newField); changed {
+				changes = append(changes, Change{
+					Kind:        ChangeTypeChanged,
+					Table:       table,
+					Field:       name,
+					Detail:      detail,
+					Destructive: destructive,
+					OldField:    &oldField,
+					NewField:    &newField,
+					OldSchema:   &old,
+					NewSchema:   &newer,
+				})
+			}
+			if detail, destructive, changed := fieldConstraintDiff(oldField, newField); changed {
+				changes = append(changes, Change{
+					Kind:        ChangeConstraintChanged,
+					Table:       table,
+					Field:       name,
+					Detail:      detail,
+					Destructive: destructive,
+					OldField:    &oldField,
+					NewField:    &newField,
+					OldSchema:   &old,
+					NewSchema:   &newer,
+				})
+			}
+		}
+	}
+	return changes, nil
+}
+
+// isNarrowing reports whether converting from to is likely to lose data,
+// e.g. int64 -> int32 or float64 -> float32.
+func isNarrowing(from, to DataType) bool {
+	switch {
+	case from == DataTypeInt64 && to == DataTypeInt32:
+		return true
+	case from == DataTypeFloat64 && to == DataTypeFloat32:
+		return true
+	case from.IsNumeric() && to == DataTypeString:
+		return false
+	case from == DataTypeString && to != DataTypeString:
+		return true
+	default:
+		return false
+	}
+}
+
+// fieldTypeDiff reports whether any of oldField's and newField's
+// type-shape attributes differ: not just DataType, but everything that
+// feeds postgresColumnType's rendering (MaxLength, Precision/Scale,
+// ElementType, Values/EnumRef, TimePrecision, AutoIncrement), so a
+// narrowed varchar or a changed decimal precision is caught the same way
+// a changed DataType is.
+func fieldTypeDiff(oldField, newField TableField) (detail string, destructive bool, changed bool) {
+	changed = oldField.DataType != newField.DataType ||
+		oldField.MaxLength != newField.MaxLength ||
+		oldField.Precision != newField.Precision ||
+		oldField.Scale != newField.Scale ||
+		oldField.ElementType != newField.ElementType ||
+		oldField.TimePrecision != newField.TimePrecision ||
+		oldField.AutoIncrement != newField.AutoIncrement ||
+		oldField.EnumRef != newField.EnumRef ||
+		!slices.Equal(oldField.Values, newField.Values)
+	if !changed {
+		return "", false, false
+	}
+
+	destructive = isNarrowing(oldField.DataType, newField.DataType)
+	if oldField.DataType == newField.DataType {
+		if newField.MaxLength != 0 && (oldField.MaxLength == 0 || newField.MaxLength < oldField.MaxLength) {
+			destructive = true
+		}
+		if newField.Precision != 0 && newField.Precision < oldField.Precision {
+			destructive = true
+		}
+		if newField.Scale < oldField.Scale {
+			destructive = true
+		}
+	}
+	return fmt.Sprintf("%s -> %s", fieldTypeDetail(oldField), fieldTypeDetail(newField)), destructive, true
+}
+
+// fieldTypeDetail renders f's type for a Change's Detail text, including
+// whichever type-shape attribute actually carries its shape (length,
+// precision/scale, element type, or enum values), so a Detail like
+// "string -> string" can't hide a MaxLength-only change.
+func fieldTypeDetail(f TableField) string {
+	switch {
+	case f.DataType == DataTypeString && f.MaxLength > 0:
+		return fmt.Sprintf("%s(%d)", f.DataType, f.MaxLength)
+	case f.DataType == DataTypeDecimal:
+		return fmt.Sprintf("%s(%d,%d)", f.DataType, f.Precision, f.Scale)
+	case f.DataType == DataTypeArray:
+		return fmt.Sprintf("%s<%s>", f.DataType, f.ElementType)
+	case f.DataType == DataTypeEnum && f.EnumRef != "":
+		return fmt.Sprintf("%s(%s)", f.DataType, f.EnumRef)
+	case f.DataType == DataTypeEnum:
+		return fmt.Sprintf("%s%v", f.DataType, f.Values)
+	case f.DataType.IsTemporal() && f.TimePrecision > 0:
+		return fmt.Sprintf("%s(%d)", f.DataType, f.TimePrecision)
+	case f.AutoIncrement:
+		return fmt.Sprintf("%s auto_increment", f.DataType)
+	default:
+		return string(f.DataType)
+	}
+}
+
+// fieldConstraintDiff reports every non-type-shape attribute that differs
+// between oldField and newField: required/unique/default, plus the
+// constraints added by later requests (check, collation, pattern,
+// immutable, encrypted, comment, sensitivity, bounds, tags, and the
+// on_update trigger flag) that earlier only a DataType or required/
+// unique/default change would surface.
+func fieldConstraintDiff(oldField, newField TableField) (detail string, destructive bool, changed bool) {
+	var parts []string
+	note := func(format string, args ...any) { parts = append(parts, fmt.Sprintf(format, args...)) }
+
+	if oldField.Required != newField.Required {
+		note("required %t->%t", oldField.Required, newField.Required)
+		if newField.Required && !oldField.Required {
+			destructive = true
+		}
+	}
+	if oldField.Unique != newField.Unique || oldField.UniqueNullsNotDistinct != newField.UniqueNullsNotDistinct {
+		note("unique %t->%t, unique_nulls_not_distinct %t->%t", oldField.Unique, newField.Unique, oldField.UniqueNullsNotDistinct, newField.UniqueNullsNotDistinct)
+	}
+	if oldField.Default != newField.Default || oldField.DefaultExpr != newField.DefaultExpr {
+		note("default %q->%q", oldField.Default, newField.Default)
+	}
+	if oldField.Check != newField.Check {
+		note("check %q->%q", oldField.Check, newField.Check)
+	}
+	if oldField.Collation != newField.Collation {
+		note("collation %q->%q", oldField.Collation, newField.Collation)
+	}
+	if oldField.Pattern != newField.Pattern {
+		note("pattern %q->%q", oldField.Pattern, newField.Pattern)
+	}
+	if oldField.MaxBytes != newField.MaxBytes {
+		note("max_bytes %d->%d", oldField.MaxBytes, newField.MaxBytes)
+	}
+	if oldField.MaxItems != newField.MaxItems {
+		note("max_items %d->%d", oldField.MaxItems, newField.MaxItems)
+	}
+	if !floatPtrEqual(oldField.Min, newField.Min) || !floatPtrEqual(oldField.Max, newField.Max) {
+		note("min %s->%s, max %s->%s", floatPtrString(oldField.Min), floatPtrString(newField.Min), floatPtrString(oldField.Max), floatPtrString(newField.Max))
+	}
+	if oldField.Comment != newField.Comment {
+		note("comment %q->%q", oldField.Comment, newField.Comment)
+	}
+	if oldField.Immutable != newField.Immutable {
+		note("immutable %t->%t", oldField.Immutable, newField.Immutable)
+	}
+	if oldField.Encrypted != newField.Encrypted || oldField.EncryptionKeyRef != newField.EncryptionKeyRef {
+		note("encrypted %t->%t", oldField.Encrypted, newField.Encrypted)
+	}
+	if oldField.Sensitivity != newField.Sensitivity {
+		note("sensitivity %s->%s", oldField.EffectiveSensitivity(), newField.EffectiveSensitivity())
+	}
+	if oldField.Generated != newField.Generated || oldField.Stored != newField.Stored {
+		note("generated %q->%q, stored %t->%t", oldField.Generated, newField.Generated, oldField.Stored, newField.Stored)
+		destructive = true
+	}
+	if oldField.OnUpdate != newField.OnUpdate {
+		note("on_update %t->%t", oldField.OnUpdate, newField.OnUpdate)
+	}
+	if !maps.Equal(oldField.Tags, newField.Tags) {
+		note("tags changed")
+	}
+
+	if len(parts) == 0 {
+		return "", false, false
+	}
+	return strings.Join(parts, ", "), destructive, true
+}
+
+// floatPtrEqual compares two optional float64s, treating nil as distinct
+// from any set value including 0.
+func floatPtrEqual(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// floatPtrString renders an optional float64 for a Change's Detail text.
+func floatPtrString(p *float64) string {
+	if p == nil {
+		return "<unset>"
+	}
+	return fmt.Sprintf("%v", *p)
+}
+
+// ToPostgresMigration renders changes as forward ("up") and reverse
+// ("down") SQL. Adding a column maps to ADD COLUMN, removing to DROP
+// COLUMN (with the down migration re-adding it), and type changes to
+// ALTER COLUMN ... TYPE. A down migration that can't fully restore lost
+// data is emitted with a comment calling that out instead of silently
+// pretending to be reversible.
+func (changes ChangeSet) ToPostgresMigration() (up string, down string, err error) {
+	var upLines, downLines []string
+
+	for _, c := range changes {
+		switch c.Kind {
+		case ChangeAddField:
+			// Qualify with the namespace ToPostgresDDL creates under — an
+			// unqualified name resolves against search_path, which may not
+			// be the same object.
+			qualifiedName := c.NewSchema.EffectiveNamespace() + "." + c.Table
+			colType, err := postgresColumnType(c.Field, *c.NewField)
+			if err != nil {
+				return "", "", fmt.Errorf("%s.%s: %w", c.Table, c.Field, err)
+			}
+			upLines = append(upLines, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", qualifiedName, c.Field, colType))
+			downLines = append(downLines, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", qualifiedName, c.Field))
+
+		case ChangeRemoveField:
+			qualifiedName := c.NewSchema.EffectiveNamespace() + "." + c.Table
+			upLines = append(upLines, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", qualifiedName, c.Field))
+			colType, err := postgresColumnType(c.Field, *c.OldField)
+			if err != nil {
+				return "", "", fmt.Errorf("%s.%s: %w", c.Table, c.Field, err)
+			}
+			downLines = append(downLines,
+				fmt.Sprintf("-- data loss: %s.%s's values cannot be recovered", c.Table, c.Field),
+				fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", qualifiedName, c.Field, colType))
+
+		case ChangeTypeChanged:
+			qualifiedName := c.NewSchema.EffectiveNamespace() + "." + c.Table
+			newType, err := postgresColumnType(c.Field, *c.NewField)
+			if err != nil {
+				return "", "", fmt.Errorf("%s.%s: %w", c.Table, c.Field, err)
+			}
+			oldType, err := postgresColumnType(c.Field, *c.OldField)
+			if err != nil {
+				return "", "", fmt.Errorf("%s.%s: %w", c.Table, c.Field, err)
+			}
+			upLine := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", qualifiedName, c.Field, newType)
+			downLine := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s;", qualifiedName, c.Field, oldType)
+			if c.Destructive {
+				upLine = fmt.Sprintf("-- potentially destructive: narrowing %s.%s from %s to %s\n%s", c.Table, c.Field, oldType, newType, upLine)
+			}
+			upLines = append(upLines, upLine)
+			downLines = append(downLines, downLine)
+
+		case ChangeViewChanged:
+			// Qualify with the namespace ToPostgresDDL creates under — an
+			// unqualified name resolves against search_path, which may not
+			// be the same object.
+			oldQualifiedName := c.OldSchema.EffectiveNamespace() + "." + c.Table
+			newQualifiedName := c.NewSchema.EffectiveNamespace() + "." + c.Table
+			if c.OldSchema.EffectiveKind() != c.NewSchema.EffectiveKind() {
+				// Kind itself flipped between table and view: a plain
+				// CREATE OR REPLACE VIEW can't turn a table into a view or
+				// back, so drop the old shape and recreate the new one in
+				// full, in both directions.
+				newDDL, err := c.NewSchema.ToPostgresDDL()
+				if err != nil {
+					return "", "", fmt.Errorf("%s: %w", c.Table, err)
+				}
+				oldDDL, err := c.OldSchema.ToPostgresDDL()
+				if err != nil {
+					return "", "", fmt.Errorf("%s: %w", c.Table, err)
+				}
+				upDrop := fmt.Sprintf("DROP VIEW IF EXISTS %s;", oldQualifiedName)
+				downDrop := fmt.Sprintf("DROP VIEW IF EXISTS %s;", newQualifiedName)
+				if !c.OldSchema.IsView() {
+					upDrop = fmt.Sprintf("DROP TABLE IF EXISTS %s;", oldQualifiedName)
+				}
+				if !c.NewSchema.IsView() {
+					downDrop = fmt.Sprintf("DROP TABLE IF EXISTS %s;", newQualifiedName)
+				}
+				upLines = append(upLines, upDrop, newDDL)
+				downLines = append(downLines, downDrop, oldDDL)
+			} else if c.NewQuery != "" {
+				upLines = append(upLines, fmt.Sprintf("CREATE OR REPLACE VIEW %s AS\n%s;", newQualifiedName, c.NewQuery))
+				downLines = append(downLines, fmt.Sprintf("CREATE OR REPLACE VIEW %s AS\n%s;", oldQualifiedName, c.OldQuery))
+			}
+
+		case ChangeConstraintChanged:
+			qualifiedName := c.NewSchema.EffectiveNamespace() + "." + c.Table
+			if c.OldField.Required != c.NewField.Required {
+				if c.NewField.Required {
+					upLines = append(upLines, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", qualifiedName, c.Field))
+					downLines = append(downLines, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;", qualifiedName, c.Field))
+				} else {
+					upLines = append(upLines, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;", qualifiedName, c.Field))
+					downLines = append(downLines, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;", qualifiedName, c.Field))
+				}
+			}
+			if c.OldField.Default != c.NewField.Default || c.OldField.DefaultExpr != c.NewField.DefaultExpr {
+				if c.NewField.Default != "" {
+					upLines = append(upLines, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s;", qualifiedName, c.Field, postgresDefaultLiteral(*c.NewField)))
+				} else {
+					upLines = append(upLines, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT;", qualifiedName, c.Field))
+				}
+				if c.OldField.Default != "" {
+					downLines = append(downLines, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s;", qualifiedName, c.Field, postgresDefaultLiteral(*c.OldField)))
+				} else {
+					downLines = append(downLines, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT;", qualifiedName, c.Field))
+				}
+			}
+			if c.OldField.Unique != c.NewField.Unique || c.OldField.UniqueNullsNotDistinct != c.NewField.UniqueNullsNotDistinct {
+				name := postgresUniqueConstraintName(c.Table, c.Field)
+				if c.OldField.Unique {
+					upLines = append(upLines, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", qualifiedName, name))
+				}
+				if c.NewField.Unique {
+					upLines = append(upLines, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s UNIQUE%s (%s);", qualifiedName, name, uniqueNullsClause(c.NewField.UniqueNullsNotDistinct), c.Field))
+				}
+				if c.NewField.Unique {
+					downLines = append(downLines, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", qualifiedName, name))
+				}
+				if c.OldField.Unique {
+					downLines = append(downLines, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s UNIQUE%s (%s);", qualifiedName, name, uniqueNullsClause(c.OldField.UniqueNullsNotDistinct), c.Field))
+				}
+			}
+			if c.OldField.Check != c.NewField.Check {
+				name := postgresCheckConstraintName(c.Table, c.Field)
+				if c.OldField.Check != "" {
+					upLines = append(upLines, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", qualifiedName, name))
+				}
+				if c.NewField.Check != "" {
+					upLines = append(upLines, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s);", qualifiedName, name, c.NewField.Check))
+				}
+				if c.NewField.Check != "" {
+					downLines = append(downLines, fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT %s;", qualifiedName, name))
+				}
+				if c.OldField.Check != "" {
+					downLines = append(downLines, fmt.Sprintf("ALTER TABLE %s ADD CONSTRAINT %s CHECK (%s);", qualifiedName, name, c.OldField.Check))
+				}
+			}
+			if c.OldField.Collation != c.NewField.Collation {
+				newType, err := postgresColumnType(c.Field, *c.NewField)
+				if err != nil {
+					return "", "", fmt.Errorf("%s.%s: %w", c.Table, c.Field, err)
+				}
+				oldType, err := postgresColumnType(c.Field, *c.OldField)
+				if err != nil {
+					return "", "", fmt.Errorf("%s.%s: %w", c.Table, c.Field, err)
+				}
+				upLines = append(upLines, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s%s;", qualifiedName, c.Field, newType, collateClause(c.NewField.Collation)))
+				downLines = append(downLines, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s%s;", qualifiedName, c.Field, oldType, collateClause(c.OldField.Collation)))
+			}
+			if oldComment, newComment := postgresColumnComment(*c.OldField), postgresColumnComment(*c.NewField); oldComment != newComment {
+				upLines = append(upLines, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", qualifiedName, c.Field, commentLiteral(newComment)))
+				downLines = append(downLines, fmt.Sprintf("COMMENT ON COLUMN %s.%s IS %s;", qualifiedName, c.Field, commentLiteral(oldComment)))
+			}
+			if c.OldField.Immutable != c.NewField.Immutable {
+				upLines = append(upLines, immutableTriggerSQL(c.Table, qualifiedName, c.Field, c.NewField.Immutable))
+				downLines = append(downLines, immutableTriggerSQL(c.Table, qualifiedName, c.Field, c.OldField.Immutable))
+			}
+			if c.OldField.OnUpdate != c.NewField.OnUpdate {
+				upLines = append(upLines, onUpdateTriggerSQL(c.Table, qualifiedName, c.Field, c.NewField.OnUpdate))
+				downLines = append(downLines, onUpdateTriggerSQL(c.Table, qualifiedName, c.Field, c.OldField.OnUpdate))
+			}
+			if c.OldField.Generated != c.NewField.Generated || c.OldField.Stored != c.NewField.Stored {
+				note := fmt.Sprintf("-- manual migration needed: %s.%s's generated expression changed, changing a GENERATED column isn't done with a plain ALTER", c.Table, c.Field)
+				upLines = append(upLines, note)
+				downLines = append(downLines, note)
+			}
+		}
+	}
+	return strings.Join(upLines, "\n"), strings.Join(downLines, "\n"), nil
+}
+
+// uniqueNullsClause renders the " NULLS NOT DISTINCT" suffix for an ADD
+// CONSTRAINT ... UNIQUE clause, matching toPostgresDDL's inline rendering.
+func uniqueNullsClause(nullsNotDistinct bool) string {
+	if nullsNotDistinct {
+		return " NULLS NOT DISTINCT"
+	}
+	return ""
+}
+
+// collateClause renders the " COLLATE "name"" suffix for an ALTER COLUMN
+// ... TYPE clause, matching toPostgresDDL's inline rendering.
+func collateClause(collation string) string {
+	if collation == "" {
+		return ""
+	}
+	return fmt.Sprintf(" COLLATE %q", collation)
+}
+
+// commentLiteral renders comment as a COMMENT ON ... IS argument: NULL to
+// clear the comment, or a quoted string literal to set it.
+func commentLiteral(comment string) string {
+	if comment == "" {
+		return "NULL"
+	}
+	return postgresStringLiteral(comment)
+}
+
+// postgresUniqueConstraintName mirrors Postgres's own default unique
+// constraint naming (<table>_<field>_key), so a migration's ADD/DROP
+// CONSTRAINT names the constraint the same way an inline UNIQUE would
+// have.
+func postgresUniqueConstraintName(table, field string) string {
+	return fmt.Sprintf("%s_%s_key", table, field)
+}
+
+// postgresCheckConstraintName mirrors Postgres's own default check
+// constraint naming (<table>_<field>_check), so a migration's ADD/DROP
+// CONSTRAINT names the constraint the same way an inline CHECK would
+// have.
+func postgresCheckConstraintName(table, field string) string {
+	return fmt.Sprintf("%s_%s_check", table, field)
+}
+
+// immutableTriggerSQL renders the CREATE (enabled) or DROP (disabled)
+// statement for field's immutability trigger, reusing the same function/
+// trigger names and body toPostgresDDL emits inline at table-creation
+// time, so a migration's trigger matches one a fresh CREATE TABLE would
+// produce.
+// table is the bare table name, used for the function/trigger names
+// toPostgresDDL derives it from; qualifiedTable is the namespace-qualified
+// name the trigger is actually attached to.
+func immutableTriggerSQL(table, qualifiedTable, field string, enabled bool) string {
+	fn := immutableFuncName(table, field)
+	trigger := immutableTriggerName(table, field)
+	if !enabled {
+		return fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s;\nDROP FUNCTION IF EXISTS %s();", trigger, qualifiedTable, fn)
+	}
+	return fmt.Sprintf("CREATE OR REPLACE FUNCTION %s() RETURNS trigger AS $$\nBEGIN\n  IF NEW.%s IS DISTINCT FROM OLD.%s THEN\n    RAISE EXCEPTION '%s.%s is immutable';\n  END IF;\n  RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql;\n\nCREATE TRIGGER %s\n  BEFORE UPDATE ON %s\n  FOR EACH ROW\n  EXECUTE FUNCTION %s();",
+		fn, field, field, table, field, trigger, qualifiedTable, fn)
+}
+
+// onUpdateTriggerSQL renders the CREATE (enabled) or DROP (disabled)
+// statement for field's on-update-refresh trigger, reusing the same
+// function/trigger names and body toPostgresDDL emits inline at table-
+// creation time, so a migration's trigger matches one a fresh CREATE
+// TABLE would produce. table is the bare table name, used for the
+// function/trigger names; qualifiedTable is the namespace-qualified name
+// the trigger is actually attached to.
+func onUpdateTriggerSQL(table, qualifiedTable, field string, enabled bool) string {
+	fn := onUpdateFuncName(table, field)
+	trigger := onUpdateTriggerName(table, field)
+	if !enabled {
+		return fmt.Sprintf("DROP TRIGGER IF EXISTS %s ON %s;\nDROP FUNCTION IF EXISTS %s();", trigger, qualifiedTable, fn)
+	}
+	return fmt.Sprintf("CREATE OR REPLACE FUNCTION %s() RETURNS trigger AS $$\nBEGIN\n  NEW.%s = now();\n  RETURN NEW;\nEND;\n$$ LANGUAGE plpgsql;\n\nCREATE TRIGGER %s\n  BEFORE UPDATE ON %s\n  FOR EACH ROW\n  EXECUTE FUNCTION %s();",
+		fn, field, trigger, qualifiedTable, fn)
+}
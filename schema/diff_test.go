@@ -0,0 +1,147 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func baseUsersSchema() TableSchema {
+	return TableSchema{
+		Name:       "users",
+		PrimaryKey: []string{"id"},
+		Fields: map[string]TableField{
+			"id": {DataType: DataTypeUUID, Required: true, Automatic: true},
+			"email": {
+				DataType:  DataTypeString,
+				MaxLength: 100,
+				Required:  true,
+			},
+		},
+	}
+}
+
+func withEmail(s TableSchema, modify func(f *TableField)) TableSchema {
+	f := s.Fields["email"]
+	modify(&f)
+	s.Fields["email"] = f
+	return s
+}
+
+func TestDiffSchemasDetectsFieldAttributeChanges(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(f *TableField)
+		kind   ChangeKind
+	}{
+		{"max_length narrowed", func(f *TableField) { f.MaxLength = 5 }, ChangeTypeChanged},
+		{"check added", func(f *TableField) { f.Check = "email ~ '@'" }, ChangeConstraintChanged},
+		{"pattern added", func(f *TableField) { f.Pattern = "^.+@.+$" }, ChangeConstraintChanged},
+		{"immutable flipped", func(f *TableField) { f.Immutable = true }, ChangeConstraintChanged},
+		{"collation changed", func(f *TableField) { f.Collation = "C" }, ChangeConstraintChanged},
+		{"unique flipped", func(f *TableField) { f.Unique = true }, ChangeConstraintChanged},
+		{"comment changed", func(f *TableField) { f.Comment = "primary contact" }, ChangeConstraintChanged},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			old := baseUsersSchema()
+			newer := withEmail(baseUsersSchema(), c.modify)
+
+			changes, err := DiffSchemas(old, newer)
+			if err != nil {
+				t.Fatalf("DiffSchemas: %v", err)
+			}
+			var found bool
+			for _, change := range changes {
+				if change.Kind == c.kind && change.Field == "email" {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatalf("expected a %s change for email, got %+v", c.kind, changes)
+			}
+		})
+	}
+}
+
+func TestDiffSchemasNoChangeIsEmpty(t *testing.T) {
+	s := baseUsersSchema()
+	changes, err := DiffSchemas(s, s)
+	if err != nil {
+		t.Fatalf("DiffSchemas: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected no changes for an identical schema, got %+v", changes)
+	}
+}
+
+func TestDiffSchemasMarksNarrowingDestructive(t *testing.T) {
+	old := baseUsersSchema()
+	newer := withEmail(baseUsersSchema(), func(f *TableField) { f.MaxLength = 5 })
+
+	changes, err := DiffSchemas(old, newer)
+	if err != nil {
+		t.Fatalf("DiffSchemas: %v", err)
+	}
+	for _, change := range changes {
+		if change.Kind == ChangeTypeChanged && change.Field == "email" {
+			if !change.Destructive {
+				t.Fatalf("expected narrowing max_length to be flagged destructive")
+			}
+			return
+		}
+	}
+	t.Fatalf("expected a type_changed change for email, got %+v", changes)
+}
+
+func TestToPostgresMigrationEmitsUniqueConstraint(t *testing.T) {
+	old := baseUsersSchema()
+	newer := withEmail(baseUsersSchema(), func(f *TableField) { f.Unique = true })
+
+	changes, err := DiffSchemas(old, newer)
+	if err != nil {
+		t.Fatalf("DiffSchemas: %v", err)
+	}
+	up, down, err := changes.ToPostgresMigration()
+	if err != nil {
+		t.Fatalf("ToPostgresMigration: %v", err)
+	}
+	if !containsLine(up, "ALTER TABLE public.users ADD CONSTRAINT users_email_key UNIQUE (email);") {
+		t.Fatalf("expected up migration to add a unique constraint, got:\n%s", up)
+	}
+	if !containsLine(down, "ALTER TABLE public.users DROP CONSTRAINT users_email_key;") {
+		t.Fatalf("expected down migration to drop the unique constraint, got:\n%s", down)
+	}
+}
+
+func TestToPostgresMigrationQualifiesNonDefaultNamespace(t *testing.T) {
+	old := baseUsersSchema()
+	old.Namespace = "billing"
+	base := baseUsersSchema()
+	base.Namespace = "billing"
+	newer := withEmail(base, func(f *TableField) { f.Unique = true })
+
+	changes, err := DiffSchemas(old, newer)
+	if err != nil {
+		t.Fatalf("DiffSchemas: %v", err)
+	}
+	up, down, err := changes.ToPostgresMigration()
+	if err != nil {
+		t.Fatalf("ToPostgresMigration: %v", err)
+	}
+	if !containsLine(up, "ALTER TABLE billing.users ADD CONSTRAINT users_email_key UNIQUE (email);") {
+		t.Fatalf("expected up migration to target the billing namespace, got:\n%s", up)
+	}
+	if !containsLine(down, "ALTER TABLE billing.users DROP CONSTRAINT users_email_key;") {
+		t.Fatalf("expected down migration to target the billing namespace, got:\n%s", down)
+	}
+}
+
+func containsLine(text, line string) bool {
+	for _, l := range strings.Split(text, "\n") {
+		if l == line {
+			return true
+		}
+	}
+	return false
+}
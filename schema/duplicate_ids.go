@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// IDCollision reports a single id shared by more than one table or field
+// across every schema in Dir. Locations are "table" for a table id or
+// "table.field" for a field id, sorted for deterministic output.
+type IDCollision struct {
+	Id        uuid.UUID
+	Locations []string
+}
+
+// String renders an IDCollision as a one-line human summary.
+func (c IDCollision) String() string {
+	loc := c.Locations[0]
+	for _, l := range c.Locations[1:] {
+		loc += ", " + l
+	}
+	return fmt.Sprintf("id %s reused at %s", c.Id, loc)
+}
+
+// FindDuplicateIDs loads every schema in Dir and reports each id, table or
+// field, that's reused across more than one location. This catches the
+// common mistake of copying a schema file as a starting point for a new
+// table and forgetting to regenerate its ids, which would otherwise
+// confuse any tooling that keys off of them (e.g. migration generation).
+func FindDuplicateIDs() ([]IDCollision, error) {
+	schemas, err := AllSchemas()
+	if err != nil {
+		return nil, err
+	}
+	return DuplicateIDsIn(schemas), nil
+}
+
+// DuplicateIDsIn reports each id, table or field, that's reused across
+// more than one location within schemas, the same check FindDuplicateIDs
+// runs, for a caller that already has a schema set loaded and wants to
+// avoid reading every schema file from Dir a second time. A zero-value
+// (unset) id is never reported as a collision, since an unset id means
+// "not yet generated", not "deliberately shared".
+func DuplicateIDsIn(schemas []TableSchema) []IDCollision {
+	locations := map[uuid.UUID][]string{}
+	for _, s := range schemas {
+		if s.Id != uuid.Nil {
+			locations[s.Id] = append(locations[s.Id], s.Name)
+		}
+		for name, f := range s.Fields {
+			if f.Id != uuid.Nil {
+				locations[f.Id] = append(locations[f.Id], fmt.Sprintf("%s.%s", s.Name, name))
+			}
+		}
+	}
+
+	var collisions []IDCollision
+	for id, locs := range locations {
+		if len(locs) < 2 {
+			continue
+		}
+		sort.Strings(locs)
+		collisions = append(collisions, IDCollision{Id: id, Locations: locs})
+	}
+	sort.Slice(collisions, func(i, j int) bool {
+		return collisions[i].Locations[0] < collisions[j].Locations[0]
+	})
+	return collisions
+}
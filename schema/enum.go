@@ -0,0 +1,46 @@
+package schema
+
+import "fmt"
+
+// EnumDefinition is a named, reusable set of enum values declared once in
+// dbexp.toml's [[enums]] and shared by every TableField that sets EnumRef
+// to its Name, instead of repeating Values inline.
+type EnumDefinition struct {
+	Name   string   `toml:"name"`
+	Values []string `toml:"values"`
+}
+
+// projectEnums is the enum name -> EnumDefinition lookup populated by
+// RegisterEnum, which the CLI calls once per dbexp.toml [[enums]] entry.
+// It starts empty, so EnumRef is unresolvable (and rejected by validation)
+// without something registering the enum first.
+var projectEnums = map[string]EnumDefinition{}
+
+// RegisterEnum adds def to the project enum registry under def.Name,
+// overriding any existing definition under that name. Call it for every
+// named enum a caller wants EnumRef fields to resolve against.
+func RegisterEnum(def EnumDefinition) {
+	projectEnums[def.Name] = def
+}
+
+// resolveEnumValues returns f's allowed enum values: f.Values directly for
+// an inline enum, or the referenced EnumDefinition's Values for EnumRef. It
+// errors if EnumRef names an enum that isn't declared in dbexp.toml.
+func resolveEnumValues(f TableField) ([]string, error) {
+	if f.EnumRef == "" {
+		return f.Values, nil
+	}
+	def, ok := projectEnums[f.EnumRef]
+	if !ok {
+		return nil, fmt.Errorf("unknown enum %q, declared project enums: %v", f.EnumRef, enumNames())
+	}
+	return def.Values, nil
+}
+
+func enumNames() []string {
+	names := make([]string, 0, len(projectEnums))
+	for name := range projectEnums {
+		names = append(names, name)
+	}
+	return names
+}
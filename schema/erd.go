@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// mermaidEntityName renders a table name as a Mermaid ER entity identifier.
+// Mermaid entity names can't contain spaces, so this is mostly a pass
+// through for the identifiers this CLI already validates.
+func mermaidEntityName(table string) string {
+	return strings.ToUpper(table)
+}
+
+// BuildERD renders tables as a Mermaid erDiagram block: one entity per
+// table, its fields as attributes, and one relationship edge per foreign
+// key reference. A reference from a unique field is rendered one-to-one;
+// otherwise many-to-one, since many rows in the referencing table can
+// point at the same target row.
+func BuildERD(tables []TableSchema) string {
+	byName := make(map[string]TableSchema, len(tables))
+	for _, t := range tables {
+		byName[t.Name] = t
+	}
+
+	sorted := make([]TableSchema, len(tables))
+	copy(sorted, tables)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	var b strings.Builder
+	b.WriteString("erDiagram\n")
+
+	for _, s := range sorted {
+		entity := mermaidEntityName(s.Name)
+		fmt.Fprintf(&b, "    %s {\n", entity)
+		for _, name := range s.FieldOrder() {
+			f := s.Fields[name]
+			fmt.Fprintf(&b, "        %s %s\n", f.DataType, name)
+		}
+		b.WriteString("    }\n")
+	}
+
+	for _, s := range sorted {
+		for _, name := range s.FieldOrder() {
+			f := s.Fields[name]
+			if f.References == nil {
+				continue
+			}
+			if _, ok := byName[f.References.Table]; !ok {
+				continue
+			}
+			from := mermaidEntityName(f.References.Table)
+			to := mermaidEntityName(s.Name)
+			many := "o{"
+			if f.Unique {
+				many = "||"
+			}
+			fmt.Fprintf(&b, "    %s ||--%s %s : %s\n", from, many, to, name)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,14 @@
+package schema
+
+import "errors"
+
+// ErrNotFound wraps a lookup failure for a table or file that doesn't
+// exist, e.g. LoadSchemaByName given a name with no matching schema file.
+// Callers check for it with errors.Is.
+var ErrNotFound = errors.New("not found")
+
+// ErrValidation wraps a TableSchema that fails Validate's structural
+// checks. The wrapped error still carries the individual failures,
+// joined, so a caller that doesn't care about the distinction can just
+// print it.
+var ErrValidation = errors.New("validation failed")
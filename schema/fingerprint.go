@@ -0,0 +1,99 @@
+package schema
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+)
+
+// Fingerprint returns a stable hash of s's structural content: field
+// names, types, and constraints. Field ids and map/slice ordering are
+// excluded so cosmetic reordering or regenerated ids don't change the
+// result, only real structural edits do.
+func (s TableSchema) Fingerprint() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "name=%s namespace=%s kind=%s query=%s\n", s.Name, s.EffectiveNamespace(), s.EffectiveKind(), s.Query)
+
+	names := make([]string, 0, len(s.Fields))
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(h, "field=%s %s\n", name, fieldFingerprint(s.Fields[name]))
+	}
+
+	pk := append([]string{}, s.PrimaryKey...)
+	sort.Strings(pk)
+	fmt.Fprintf(h, "primary_key=%v\n", pk)
+
+	unique := make([]string, 0, len(s.Unique))
+	for _, group := range s.Unique {
+		sorted := append([]string{}, group...)
+		sort.Strings(sorted)
+		unique = append(unique, fmt.Sprintf("%v", sorted))
+	}
+	sort.Strings(unique)
+	fmt.Fprintf(h, "unique=%v\n", unique)
+
+	if p := s.Partition; p != nil {
+		fields := append([]string{}, p.Fields...)
+		sort.Strings(fields)
+		fmt.Fprintf(h, "partition=%s %v\n", p.Strategy, fields)
+	}
+
+	checks := append([]TableCheck{}, s.Checks...)
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Name < checks[j].Name })
+	for _, check := range checks {
+		fmt.Fprintf(h, "check=%s %s\n", check.Name, check.Expression)
+	}
+
+	foreignKeys := append([]ForeignKey{}, s.ForeignKeys...)
+	sort.Slice(foreignKeys, func(i, j int) bool { return fmt.Sprint(foreignKeys[i].Columns) < fmt.Sprint(foreignKeys[j].Columns) })
+	for _, fk := range foreignKeys {
+		fmt.Fprintf(h, "foreign_key=%v namespace=%s ref_table=%s ref_columns=%v on_delete=%s on_update=%s\n", fk.Columns, fk.Namespace, fk.RefTable, fk.RefColumns, fk.OnDelete, fk.OnUpdate)
+	}
+
+	indexes := append([]Index{}, s.Indexes...)
+	sort.Slice(indexes, func(i, j int) bool { return indexes[i].Name < indexes[j].Name })
+	for _, idx := range indexes {
+		fields := append([]string{}, idx.Fields...)
+		sort.Strings(fields)
+		fmt.Fprintf(h, "index=%s %v unique=%t method=%s where=%s\n", idx.Name, fields, idx.Unique, idx.Method, idx.Where)
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// fieldFingerprint renders a TableField's structural attributes,
+// deliberately omitting its Id.
+func fieldFingerprint(f TableField) string {
+	values := append([]string{}, f.Values...)
+	sort.Strings(values)
+
+	ref := ""
+	if f.References != nil {
+		ref = fmt.Sprintf("%s.%s.%s on_delete=%s on_update=%s", f.References.Namespace, f.References.Table, f.References.Field, f.References.OnDelete, f.References.OnUpdate)
+	}
+
+	min := ""
+	if f.Min != nil {
+		min = fmt.Sprintf("%v", *f.Min)
+	}
+	max := ""
+	if f.Max != nil {
+		max = fmt.Sprintf("%v", *f.Max)
+	}
+
+	tags := make([]string, 0, len(f.Tags))
+	for k, v := range f.Tags {
+		tags = append(tags, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(tags)
+
+	return fmt.Sprintf(
+		"type=%s max_length=%d max_bytes=%d max_items=%d precision=%d scale=%d min=%s max=%s values=%v enum_ref=%s element_type=%s references=%s default=%s default_expr=%t check=%s pattern=%s collation=%s unique=%t unique_nulls_not_distinct=%t required=%t automatic=%t on_update=%t uuid_version=%d time_precision=%d generated=%s stored=%t comment=%s auto_increment=%t encrypted=%t encryption_key_ref=%s immutable=%t sensitivity=%s tags=%v",
+		f.DataType, f.MaxLength, f.MaxBytes, f.MaxItems, f.Precision, f.Scale, min, max, values, f.EnumRef, f.ElementType, ref, f.Default, f.DefaultExpr, f.Check, f.Pattern, f.Collation, f.Unique, f.UniqueNullsNotDistinct, f.Required, f.Automatic, f.OnUpdate, f.UUIDVersion, f.TimePrecision, f.Generated, f.Stored, f.Comment, f.AutoIncrement, f.Encrypted, f.EncryptionKeyRef, f.Immutable, f.EffectiveSensitivity(), tags,
+	)
+}
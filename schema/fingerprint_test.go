@@ -0,0 +1,78 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestFingerprintDetectsAttributeChanges(t *testing.T) {
+	base := func() TableSchema {
+		return TableSchema{
+			Name:       "users",
+			PrimaryKey: []string{"id"},
+			Fields: map[string]TableField{
+				"id": {DataType: DataTypeUUID, Required: true, Automatic: true},
+				"email": {
+					DataType:  DataTypeString,
+					MaxLength: 100,
+					Required:  true,
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name   string
+		modify func(s *TableSchema)
+	}{
+		{"immutable", func(s *TableSchema) { f := s.Fields["email"]; f.Immutable = true; s.Fields["email"] = f }},
+		{"collation", func(s *TableSchema) { f := s.Fields["email"]; f.Collation = "C"; s.Fields["email"] = f }},
+		{"sensitivity", func(s *TableSchema) { f := s.Fields["email"]; f.Sensitivity = "restricted"; s.Fields["email"] = f }},
+		{"encrypted", func(s *TableSchema) { f := s.Fields["email"]; f.Encrypted = true; s.Fields["email"] = f }},
+		{"comment", func(s *TableSchema) { f := s.Fields["email"]; f.Comment = "contact address"; s.Fields["email"] = f }},
+		{"generated", func(s *TableSchema) {
+			f := s.Fields["email"]
+			f.Generated = "lower(email)"
+			f.Stored = true
+			s.Fields["email"] = f
+		}},
+		{"tags", func(s *TableSchema) {
+			f := s.Fields["email"]
+			f.Tags = map[string]string{"pii": "true"}
+			s.Fields["email"] = f
+		}},
+		{"max_bytes", func(s *TableSchema) { f := s.Fields["email"]; f.MaxBytes = 512; s.Fields["email"] = f }},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			before := base()
+			after := base()
+			c.modify(&after)
+			if before.Fingerprint() == after.Fingerprint() {
+				t.Fatalf("expected fingerprint to change after %s", c.name)
+			}
+		})
+	}
+}
+
+func TestFingerprintIgnoresFieldId(t *testing.T) {
+	a := TableSchema{
+		Name:       "users",
+		PrimaryKey: []string{"id"},
+		Fields: map[string]TableField{
+			"id": {Id: uuid.MustParse("11111111-1111-1111-1111-111111111111"), DataType: DataTypeUUID, Required: true},
+		},
+	}
+	b := TableSchema{
+		Name:       "users",
+		PrimaryKey: []string{"id"},
+		Fields: map[string]TableField{
+			"id": {Id: uuid.MustParse("22222222-2222-2222-2222-222222222222"), DataType: DataTypeUUID, Required: true},
+		},
+	}
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Fatalf("expected fingerprint to ignore field id")
+	}
+}
@@ -0,0 +1,128 @@
+package schema
+
+import (
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// goTypeInfo is a DataType's Go representation: Plain for a required
+// field, Null for a nullable one, and the imports either requires.
+type goTypeInfo struct {
+	Plain   string
+	Null    string
+	Imports []string
+}
+
+var goTypeRegistry = map[DataType]goTypeInfo{
+	DataTypeUUID:        {Plain: "uuid.UUID", Null: "*uuid.UUID", Imports: []string{"github.com/google/uuid"}},
+	DataTypeTimestamp:   {Plain: "time.Time", Null: "sql.NullTime", Imports: []string{"time", "database/sql"}},
+	DataTypeTimestampTZ: {Plain: "time.Time", Null: "sql.NullTime", Imports: []string{"time", "database/sql"}},
+	DataTypeDate:        {Plain: "time.Time", Null: "sql.NullTime", Imports: []string{"time", "database/sql"}},
+	DataTypeTime:        {Plain: "time.Time", Null: "sql.NullTime", Imports: []string{"time", "database/sql"}},
+	DataTypeString:      {Plain: "string", Null: "sql.NullString", Imports: []string{"database/sql"}},
+	DataTypeInt32:       {Plain: "int32", Null: "sql.NullInt32", Imports: []string{"database/sql"}},
+	DataTypeInt64:       {Plain: "int64", Null: "sql.NullInt64", Imports: []string{"database/sql"}},
+	DataTypeBool:        {Plain: "bool", Null: "sql.NullBool", Imports: []string{"database/sql"}},
+	DataTypeFloat32:     {Plain: "float32", Null: "*float32"},
+	DataTypeFloat64:     {Plain: "float64", Null: "sql.NullFloat64", Imports: []string{"database/sql"}},
+	DataTypeDecimal:     {Plain: "string", Null: "sql.NullString", Imports: []string{"database/sql"}},
+	DataTypeJSON:        {Plain: "json.RawMessage", Null: "json.RawMessage", Imports: []string{"encoding/json"}},
+	DataTypeBytes:       {Plain: "[]byte", Null: "[]byte"},
+	DataTypeEnum:        {Plain: "string", Null: "sql.NullString", Imports: []string{"database/sql"}},
+}
+
+// ToGoStruct renders s as a Go source file in packageName defining one
+// struct with a field per s.FieldOrder(), typed from each field's
+// DataType and tagged for db and json. A nullable field uses a
+// database/sql Null* wrapper where one exists, or a pointer otherwise, so
+// the zero value is never mistaken for an explicit NULL. The result is
+// run through go/format before being returned.
+func (s TableSchema) ToGoStruct(packageName string) (string, error) {
+	names := s.FieldOrder()
+
+	imports := map[string]bool{}
+	var fieldLines []string
+	for _, name := range names {
+		f := s.Fields[name]
+		goType, err := goFieldType(f)
+		if err != nil {
+			return "", fmt.Errorf("field %q: %w", name, err)
+		}
+
+		typeKey := f.DataType
+		if typeKey == DataTypeArray {
+			typeKey = f.ElementType
+		}
+		if info, ok := goTypeRegistry[typeKey]; ok {
+			for _, imp := range info.Imports {
+				imports[imp] = true
+			}
+		}
+
+		jsonTag := name
+		if f.Nullable() {
+			jsonTag += ",omitempty"
+		}
+		fieldLines = append(fieldLines, fmt.Sprintf("\t%s %s `db:%q json:%q`", toPascalCase(name), goType, name, jsonTag))
+	}
+
+	importNames := make([]string, 0, len(imports))
+	for imp := range imports {
+		importNames = append(importNames, imp)
+	}
+	sort.Strings(importNames)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s\n\n", packageName)
+	if len(importNames) > 0 {
+		b.WriteString("import (\n")
+		for _, imp := range importNames {
+			fmt.Fprintf(&b, "\t%q\n", imp)
+		}
+		b.WriteString(")\n\n")
+	}
+	fmt.Fprintf(&b, "type %s struct {\n", capitalize(s.Name))
+	b.WriteString(strings.Join(fieldLines, "\n"))
+	b.WriteString("\n}\n")
+
+	formatted, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return "", fmt.Errorf("table %q: generated invalid go source: %w", s.Name, err)
+	}
+	return string(formatted), nil
+}
+
+// goFieldType returns f's Go type: its registered Null variant when the
+// field is nullable, its Plain variant otherwise, or, for an array, a
+// slice of its element's Plain type (array columns encode nullability at
+// the slice level, nil meaning NULL, so there's no separate Null variant
+// to choose between).
+func goFieldType(f TableField) (string, error) {
+	if f.DataType == DataTypeArray {
+		elem, ok := goTypeRegistry[f.ElementType]
+		if !ok {
+			return "", fmt.Errorf("no go mapping for element DataType %q", f.ElementType)
+		}
+		return "[]" + elem.Plain, nil
+	}
+	info, ok := goTypeRegistry[f.DataType]
+	if !ok {
+		return "", fmt.Errorf("no go mapping for DataType %q", f.DataType)
+	}
+	if f.Nullable() {
+		return info.Null, nil
+	}
+	return info.Plain, nil
+}
+
+// toPascalCase converts a snake_case identifier to PascalCase, e.g.
+// "created_at" -> "CreatedAt".
+func toPascalCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i, part := range parts {
+		parts[i] = capitalize(part)
+	}
+	return strings.Join(parts, "")
+}
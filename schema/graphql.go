@@ -0,0 +1,90 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+var graphqlTypeNames = map[DataType]string{
+	DataTypeUUID:        "UUID",
+	DataTypeTimestamp:   "DateTime",
+	DataTypeTimestampTZ: "DateTime",
+	DataTypeDate:        "DateTime",
+	DataTypeTime:        "DateTime",
+	DataTypeString:      "String",
+	DataTypeInt32:       "Int",
+	DataTypeInt64:       "Int",
+	DataTypeBool:        "Boolean",
+	DataTypeFloat32:     "Float",
+	DataTypeFloat64:     "Float",
+	DataTypeDecimal:     "Float",
+	DataTypeJSON:        "String",
+	DataTypeBytes:       "String",
+}
+
+// ToGraphQLSDL renders s as a GraphQL type definition, along with the
+// scalar declarations it depends on.
+func (s TableSchema) ToGraphQLSDL() (string, error) {
+	names := s.FieldOrder()
+
+	scalars := map[string]bool{}
+	var lines []string
+	for _, name := range names {
+		f := s.Fields[name]
+		gqlType, err := graphqlFieldType(f)
+		if err != nil {
+			return "", fmt.Errorf("field %q: %w", name, err)
+		}
+		if base := strings.TrimSuffix(strings.TrimSuffix(gqlType, "!"), "]"); base == "UUID" || base == "DateTime" {
+			scalars[base] = true
+		}
+		if f.Required {
+			gqlType += "!"
+		}
+		lines = append(lines, fmt.Sprintf("  %s: %s", name, gqlType))
+	}
+
+	var b strings.Builder
+	scalarNames := make([]string, 0, len(scalars))
+	for name := range scalars {
+		scalarNames = append(scalarNames, name)
+	}
+	sort.Strings(scalarNames)
+	for _, name := range scalarNames {
+		fmt.Fprintf(&b, "scalar %s\n", name)
+	}
+	if len(scalarNames) > 0 {
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "type %s {\n", capitalize(s.Name))
+	b.WriteString(strings.Join(lines, "\n"))
+	b.WriteString("\n}\n")
+	return b.String(), nil
+}
+
+func graphqlFieldType(f TableField) (string, error) {
+	if f.DataType == DataTypeArray {
+		elem, ok := graphqlTypeNames[f.ElementType]
+		if !ok {
+			return "", fmt.Errorf("no graphql mapping for element DataType %q", f.ElementType)
+		}
+		return fmt.Sprintf("[%s]", elem), nil
+	}
+	if f.DataType == DataTypeEnum {
+		return "String", nil
+	}
+	name, ok := graphqlTypeNames[f.DataType]
+	if !ok {
+		return "", fmt.Errorf("no graphql mapping for DataType %q", f.DataType)
+	}
+	return name, nil
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
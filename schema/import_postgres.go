@@ -0,0 +1,381 @@
+package schema
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+)
+
+// postgresTypeToDataType maps information_schema.columns.data_type to the
+// closest DataType. A type missing from this map still produces a
+// TableField, mapped to DataTypeString, so one exotic column never fails
+// the whole import.
+var postgresTypeToDataType = map[string]DataType{
+	"uuid":                        DataTypeUUID,
+	"text":                        DataTypeString,
+	"character varying":           DataTypeString,
+	"character":                   DataTypeString,
+	"smallint":                    DataTypeInt32,
+	"integer":                     DataTypeInt32,
+	"bigint":                      DataTypeInt64,
+	"boolean":                     DataTypeBool,
+	"real":                        DataTypeFloat32,
+	"double precision":            DataTypeFloat64,
+	"numeric":                     DataTypeDecimal,
+	"jsonb":                       DataTypeJSON,
+	"json":                        DataTypeJSON,
+	"bytea":                       DataTypeBytes,
+	"timestamp without time zone": DataTypeTimestamp,
+	"timestamp with time zone":    DataTypeTimestampTZ,
+	"date":                        DataTypeDate,
+	"time without time zone":      DataTypeTime,
+	"time with time zone":         DataTypeTime,
+}
+
+// pgColumn is one row of information_schema.columns for a single table.
+type pgColumn struct {
+	name       string
+	dataType   string
+	nullable   bool
+	defaultVal sql.NullString
+	maxLength  sql.NullInt64
+	precision  sql.NullInt64
+	scale      sql.NullInt64
+}
+
+// pgCastRe strips a trailing "::typename" cast Postgres appends to a
+// literal default, e.g. "'active'::character varying".
+var pgCastRe = regexp.MustCompile(`::[\w "\[\]]+$`)
+
+// ImportPostgres connects to dsn, introspects every base table in the
+// database's "public" schema via information_schema, and returns one
+// TableSchema per table with its columns, nullability, primary key,
+// unique constraints, and foreign keys. A column whose type has no
+// DataType mapping becomes a string field, reported through warn rather
+// than failing the whole import.
+func ImportPostgres(dsn string, warn func(string)) ([]TableSchema, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+
+	tableNames, err := pgTableNames(db)
+	if err != nil {
+		return nil, err
+	}
+	primaryKeys, uniques, foreignKeys, err := pgConstraints(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas []TableSchema
+	for _, tableName := range tableNames {
+		s, err := pgBuildTableSchema(db, tableName, primaryKeys, uniques, foreignKeys, warn)
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, s)
+	}
+	return schemas, nil
+}
+
+// ImportPostgresTable connects to dsn and introspects a single table the
+// same way ImportPostgres does, for a caller (diff postgres) that only
+// needs one table's live shape rather than the whole database.
+func ImportPostgresTable(dsn, tableName string, warn func(string)) (TableSchema, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return TableSchema{}, fmt.Errorf("connect: %w", err)
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		return TableSchema{}, fmt.Errorf("connect: %w", err)
+	}
+
+	primaryKeys, uniques, foreignKeys, err := pgConstraints(db)
+	if err != nil {
+		return TableSchema{}, err
+	}
+	return pgBuildTableSchema(db, tableName, primaryKeys, uniques, foreignKeys, warn)
+}
+
+// pgConstraints fetches the primary key, unique, and foreign key
+// constraints for every table in one pass, so importing or diffing a
+// single table doesn't re-scan information_schema per constraint type.
+func pgConstraints(db *sql.DB) (primaryKeys, uniques map[string][]pgConstraintRow, foreignKeys map[string]map[string]References, err error) {
+	primaryKeys, err = pgConstraintColumns(db, "PRIMARY KEY")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	uniques, err = pgConstraintColumns(db, "UNIQUE")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	foreignKeys, err = pgForeignKeys(db)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return primaryKeys, uniques, foreignKeys, nil
+}
+
+// pgBuildTableSchema assembles tableName's TableSchema from its columns
+// plus the pre-fetched constraint maps. A column whose type has no
+// DataType mapping becomes a string field, reported through warn rather
+// than failing the import.
+func pgBuildTableSchema(db *sql.DB, tableName string, primaryKeys, uniques map[string][]pgConstraintRow, foreignKeys map[string]map[string]References, warn func(string)) (TableSchema, error) {
+	columns, err := pgColumns(db, tableName)
+	if err != nil {
+		return TableSchema{}, fmt.Errorf("table %q: %w", tableName, err)
+	}
+	if len(columns) == 0 {
+		return TableSchema{}, fmt.Errorf("table %q: not found", tableName)
+	}
+
+	s := TableSchema{Id: uuid.New(), Name: tableName, Fields: map[string]TableField{}}
+
+	pkColumns := map[string]bool{}
+	for _, row := range primaryKeys[tableName] {
+		pkColumns[row.column] = true
+		s.PrimaryKey = append(s.PrimaryKey, row.column)
+	}
+
+	uniqueColumns := map[string]bool{}
+	for _, group := range groupConstraintColumns(uniques, tableName) {
+		if len(group) == 1 {
+			uniqueColumns[group[0]] = true
+		} else {
+			s.Unique = append(s.Unique, group)
+		}
+	}
+
+	for _, col := range columns {
+		dt, ok := postgresTypeToDataType[col.dataType]
+		if !ok {
+			warn(fmt.Sprintf("table %q: column %q has unsupported type %q, mapping to string", tableName, col.name, col.dataType))
+			dt = DataTypeString
+		}
+
+		f := TableField{
+			Id:       uuid.New(),
+			DataType: dt,
+			Required: !col.nullable || pkColumns[col.name],
+			Unique:   uniqueColumns[col.name],
+		}
+		if pkColumns[col.name] {
+			f.Automatic = true
+		}
+		if dt == DataTypeString && col.maxLength.Valid {
+			f.MaxLength = int(col.maxLength.Int64)
+		}
+		if dt == DataTypeDecimal {
+			f.Precision = int(col.precision.Int64)
+			f.Scale = int(col.scale.Int64)
+		}
+		if fk, ok := foreignKeys[tableName][col.name]; ok {
+			f.References = &fk
+		}
+		if col.defaultVal.Valid {
+			applyPostgresDefault(&f, col.defaultVal.String)
+		}
+
+		s.Fields[col.name] = f
+		s.Order = append(s.Order, col.name)
+	}
+
+	return s, nil
+}
+
+// applyPostgresDefault interprets raw, a column_default expression, and
+// sets f's Default/DefaultExpr/AutoIncrement accordingly: a nextval(...)
+// sequence becomes AutoIncrement, a quoted literal (after stripping its
+// ::type cast) becomes a plain Default, and anything else is carried
+// through verbatim as a DefaultExpr.
+func applyPostgresDefault(f *TableField, raw string) {
+	if strings.HasPrefix(raw, "nextval(") {
+		f.AutoIncrement = true
+		return
+	}
+
+	value := pgCastRe.ReplaceAllString(raw, "")
+	if unquoted, err := strconv.Unquote(strings.ReplaceAll(value, "'", `"`)); err == nil {
+		f.Default = unquoted
+		return
+	}
+
+	f.Default = value
+	f.DefaultExpr = true
+}
+
+// pgTableNames returns every base table name in the public schema,
+// sorted, so import order is deterministic.
+func pgTableNames(db *sql.DB) ([]string, error) {
+	rows, err := db.Query(`
+		SELECT table_name FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_type = 'BASE TABLE'
+		ORDER BY table_name`)
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("list tables: %w", err)
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
+
+// pgColumns returns tableName's columns from information_schema.columns,
+// in ordinal position order.
+func pgColumns(db *sql.DB, tableName string) ([]pgColumn, error) {
+	rows, err := db.Query(`
+		SELECT column_name, data_type, is_nullable, column_default,
+		       character_maximum_length, numeric_precision, numeric_scale
+		FROM information_schema.columns
+		WHERE table_schema = 'public' AND table_name = $1
+		ORDER BY ordinal_position`, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []pgColumn
+	for rows.Next() {
+		var col pgColumn
+		var nullable string
+		if err := rows.Scan(&col.name, &col.dataType, &nullable, &col.defaultVal,
+			&col.maxLength, &col.precision, &col.scale); err != nil {
+			return nil, err
+		}
+		col.nullable = nullable == "YES"
+		columns = append(columns, col)
+	}
+	return columns, rows.Err()
+}
+
+// pgConstraintRow is one constrained column, tagged with the constraint
+// it belongs to so pgConstraintColumns can group multi-column
+// constraints back together.
+type pgConstraintRow struct {
+	table      string
+	constraint string
+	column     string
+}
+
+// pgConstraintColumns returns, per table, every column participating in a
+// constraint of the given type ("PRIMARY KEY" or "UNIQUE"), in
+// declaration order. Use groupConstraintColumns to split a table's columns
+// back out by individual constraint.
+func pgConstraintColumns(db *sql.DB, constraintType string) (map[string][]pgConstraintRow, error) {
+	rows, err := db.Query(`
+		SELECT tc.table_name, tc.constraint_name, kcu.column_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+		  ON kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema
+		WHERE tc.table_schema = 'public' AND tc.constraint_type = $1
+		ORDER BY tc.table_name, tc.constraint_name, kcu.ordinal_position`, constraintType)
+	if err != nil {
+		return nil, fmt.Errorf("list %s constraints: %w", constraintType, err)
+	}
+	defer rows.Close()
+
+	byTable := map[string][]pgConstraintRow{}
+	for rows.Next() {
+		var row pgConstraintRow
+		if err := rows.Scan(&row.table, &row.constraint, &row.column); err != nil {
+			return nil, err
+		}
+		byTable[row.table] = append(byTable[row.table], row)
+	}
+	return byTable, rows.Err()
+}
+
+// groupConstraintColumns splits byTable[tableName]'s rows back out into
+// one []string of column names per constraint, in declaration order, so a
+// multi-column UNIQUE constraint isn't conflated with two single-column
+// ones.
+func groupConstraintColumns(byTable map[string][]pgConstraintRow, tableName string) [][]string {
+	var groups [][]string
+	var current []string
+	currentConstraint := ""
+	for _, row := range byTable[tableName] {
+		if row.constraint != currentConstraint {
+			if len(current) > 0 {
+				groups = append(groups, current)
+			}
+			current = nil
+			currentConstraint = row.constraint
+		}
+		current = append(current, row.column)
+	}
+	if len(current) > 0 {
+		groups = append(groups, current)
+	}
+	return groups
+}
+
+// pgForeignKeys returns, per table and column, the References a foreign
+// key column points at. Multi-column foreign keys aren't representable by
+// TableField.References and are skipped with no warning here; callers
+// that want to know should query information_schema directly.
+func pgForeignKeys(db *sql.DB) (map[string]map[string]References, error) {
+	rows, err := db.Query(`
+		SELECT tc.table_name, kcu.column_name, ccu.table_name, ccu.column_name,
+		       rc.update_rule, rc.delete_rule
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+		  ON kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema
+		JOIN information_schema.constraint_column_usage ccu
+		  ON ccu.constraint_name = tc.constraint_name AND ccu.table_schema = tc.table_schema
+		JOIN information_schema.referential_constraints rc
+		  ON rc.constraint_name = tc.constraint_name AND rc.constraint_schema = tc.table_schema
+		WHERE tc.table_schema = 'public' AND tc.constraint_type = 'FOREIGN KEY'
+		ORDER BY tc.table_name, tc.constraint_name`)
+	if err != nil {
+		return nil, fmt.Errorf("list foreign keys: %w", err)
+	}
+	defer rows.Close()
+
+	byTable := map[string]map[string]References{}
+	for rows.Next() {
+		var table, column, refTable, refColumn, updateRule, deleteRule string
+		if err := rows.Scan(&table, &column, &refTable, &refColumn, &updateRule, &deleteRule); err != nil {
+			return nil, err
+		}
+		if byTable[table] == nil {
+			byTable[table] = map[string]References{}
+		}
+		byTable[table][column] = References{
+			Table:    refTable,
+			Field:    refColumn,
+			OnUpdate: postgresReferentialAction(updateRule),
+			OnDelete: postgresReferentialAction(deleteRule),
+		}
+	}
+	return byTable, rows.Err()
+}
+
+// postgresReferentialAction lowercases an information_schema
+// referential_constraints rule ("CASCADE", "SET NULL", ...) into the form
+// References.OnDelete/OnUpdate expect, leaving the no-op "NO ACTION" as
+// the empty default.
+func postgresReferentialAction(rule string) string {
+	lower := strings.ToLower(rule)
+	if lower == "no action" {
+		return ""
+	}
+	return lower
+}
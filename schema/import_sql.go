@@ -0,0 +1,116 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var createTableRe = regexp.MustCompile(`(?is)CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?(?:"?\w+"?\.)?"?(\w+)"?\s*\((.*?)\)\s*;`)
+
+var sqlTypeToDataType = map[string]DataType{
+	"uuid":        DataTypeUUID,
+	"text":        DataTypeString,
+	"varchar":     DataTypeString,
+	"char":        DataTypeString,
+	"int":         DataTypeInt32,
+	"integer":     DataTypeInt32,
+	"bigint":      DataTypeInt64,
+	"boolean":     DataTypeBool,
+	"bool":        DataTypeBool,
+	"real":        DataTypeFloat32,
+	"double":      DataTypeFloat64,
+	"numeric":     DataTypeDecimal,
+	"decimal":     DataTypeDecimal,
+	"jsonb":       DataTypeJSON,
+	"json":        DataTypeJSON,
+	"bytea":       DataTypeBytes,
+	"timestamp":   DataTypeTimestamp,
+	"timestamptz": DataTypeTimestampTZ,
+	"date":        DataTypeDate,
+	"time":        DataTypeTime,
+}
+
+// ImportSQL parses the CREATE TABLE statements in data and returns one
+// TableSchema per table it understood. Unsupported constructs are skipped
+// with a warning written to warnings rather than failing the whole import.
+func ImportSQL(data []byte, warn func(string)) ([]TableSchema, error) {
+	var schemas []TableSchema
+	for _, match := range createTableRe.FindAllStringSubmatch(string(data), -1) {
+		tableName, body := match[1], match[2]
+		s := TableSchema{Id: uuid.New(), Name: tableName, Fields: map[string]TableField{}}
+
+		for _, rawCol := range splitTopLevelCommas(body) {
+			col := strings.TrimSpace(rawCol)
+			if col == "" {
+				continue
+			}
+			upper := strings.ToUpper(col)
+			if strings.HasPrefix(upper, "PRIMARY KEY") || strings.HasPrefix(upper, "CONSTRAINT") ||
+				strings.HasPrefix(upper, "FOREIGN KEY") || strings.HasPrefix(upper, "UNIQUE") || strings.HasPrefix(upper, "CHECK") {
+				warn(fmt.Sprintf("table %q: skipping unsupported column-list entry %q", tableName, col))
+				continue
+			}
+
+			fields := strings.Fields(col)
+			if len(fields) < 2 {
+				warn(fmt.Sprintf("table %q: skipping unparseable column %q", tableName, col))
+				continue
+			}
+			colName := strings.Trim(fields[0], `"`)
+			sqlType := strings.ToLower(strings.TrimSuffix(strings.SplitN(fields[1], "(", 2)[0], ","))
+			dt, ok := sqlTypeToDataType[sqlType]
+			if !ok {
+				warn(fmt.Sprintf("table %q: skipping column %q with unsupported type %q", tableName, colName, sqlType))
+				continue
+			}
+
+			f := TableField{Id: uuid.New(), DataType: dt}
+			if strings.Contains(upper, "NOT NULL") {
+				f.Required = true
+			}
+			if strings.Contains(upper, "UNIQUE") {
+				f.Unique = true
+			}
+			if strings.Contains(upper, "PRIMARY KEY") {
+				f.Required = true
+				switch {
+				case f.DataType == DataTypeUUID || f.DataType.IsTemporal():
+					f.Automatic = true
+				case f.DataType == DataTypeInt32 || f.DataType == DataTypeInt64:
+					f.Automatic = true
+					f.AutoIncrement = true
+				}
+				s.PrimaryKey = []string{colName}
+			}
+			s.Fields[colName] = f
+		}
+		schemas = append(schemas, s)
+	}
+	return schemas, nil
+}
+
+// splitTopLevelCommas splits s on commas that aren't nested inside
+// parentheses, so "numeric(10,2)" doesn't get split in two.
+func splitTopLevelCommas(s string) []string {
+	var parts []string
+	depth := 0
+	start := 0
+	for i, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	parts = append(parts, s[start:])
+	return parts
+}
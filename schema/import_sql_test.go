@@ -0,0 +1,43 @@
+package schema
+
+import "testing"
+
+func TestImportSQLIntegerPrimaryKeyValidates(t *testing.T) {
+	sql := `CREATE TABLE widgets (id integer PRIMARY KEY, name text NOT NULL);`
+	schemas, err := ImportSQL([]byte(sql), func(string) {})
+	if err != nil {
+		t.Fatalf("ImportSQL: %v", err)
+	}
+	if len(schemas) != 1 {
+		t.Fatalf("expected 1 schema, got %d", len(schemas))
+	}
+	s := schemas[0]
+	id := s.Fields["id"]
+	if !id.AutoIncrement {
+		t.Fatalf("expected id to be auto_increment, got %+v", id)
+	}
+	if id.Automatic != true {
+		t.Fatalf("expected id to be automatic, got %+v", id)
+	}
+	if err := Validate(s); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
+
+func TestImportSQLUUIDPrimaryKeyValidates(t *testing.T) {
+	sql := `CREATE TABLE widgets (id uuid PRIMARY KEY, name text NOT NULL);`
+	schemas, err := ImportSQL([]byte(sql), func(string) {})
+	if err != nil {
+		t.Fatalf("ImportSQL: %v", err)
+	}
+	id := schemas[0].Fields["id"]
+	if id.AutoIncrement {
+		t.Fatalf("expected uuid primary key not to be auto_increment, got %+v", id)
+	}
+	if !id.Automatic {
+		t.Fatalf("expected id to be automatic, got %+v", id)
+	}
+	if err := Validate(schemas[0]); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+}
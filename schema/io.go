@@ -0,0 +1,411 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Dir is the directory commands scan for schema files. It defaults to
+// "schemas" but is meant to be overridden at startup by a caller reading
+// its own project config (the CLI does this from dbexp.toml).
+var Dir = "schemas"
+
+// extensions lists the file extensions scanned for schema files, in
+// addition to whatever the caller names explicitly.
+var extensions = []string{".toml", ".json", ".yaml", ".yml"}
+
+// IsSchemaFile reports whether name has one of the recognized schema file
+// extensions.
+func IsSchemaFile(name string) bool {
+	ext := filepath.Ext(name)
+	for _, known := range extensions {
+		if ext == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Path returns the default file path for table, under Dir.
+func Path(table string) string {
+	return filepath.Join(Dir, table+".toml")
+}
+
+// unmarshalByExt decodes data into target, picking toml, json, or yaml by
+// ext. An unrecognized extension falls back to toml, the original format.
+func unmarshalByExt(data []byte, ext string, target any) error {
+	switch ext {
+	case ".json":
+		return json.Unmarshal(data, target)
+	case ".yaml", ".yml":
+		return yaml.Unmarshal(data, target)
+	default:
+		return toml.Unmarshal(data, target)
+	}
+}
+
+// SchemasFromData sniffs data's top-level shape and returns every
+// TableSchema it contains: one for a single-table file (the usual case),
+// or one per entry for a multi-table Database file. A table's map key
+// wins over any Name set inside it, so the two can't disagree. ext picks
+// the deserializer, the same way MarshalSchema's format picks an encoder.
+func SchemasFromData(data []byte, ext string) ([]TableSchema, error) {
+	var probe struct {
+		Tables map[string]TableSchema `toml:"tables" json:"tables" yaml:"tables"`
+	}
+	if err := unmarshalByExt(data, ext, &probe); err != nil {
+		return nil, err
+	}
+	if probe.Tables != nil {
+		schemas := make([]TableSchema, 0, len(probe.Tables))
+		for name, s := range probe.Tables {
+			s.Name = name
+			schemas = append(schemas, s)
+		}
+		return schemas, nil
+	}
+
+	var s TableSchema
+	if err := unmarshalByExt(data, ext, &s); err != nil {
+		return nil, err
+	}
+	return []TableSchema{s}, nil
+}
+
+// validatedSchemas parses data (a single-table or multi-table Database
+// file) and validates every table it contains, including foreign keys and
+// relationships resolved against every other schema in Dir. It returns
+// every problem found, joined into one error, rather than stopping at the
+// first.
+func validatedSchemas(data []byte, ext string) ([]TableSchema, error) {
+	schemas, err := SchemasFromData(data, ext)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for i := range schemas {
+		if schemas[i].Version == 0 {
+			schemas[i].Version = 1
+		}
+		if err := Validate(schemas[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	known := make(map[string]TableSchema, len(schemas))
+	for _, s := range schemas {
+		known[s.Name] = s
+	}
+	if others, err := AllSchemas(); err == nil {
+		for _, s := range others {
+			if _, ok := known[s.Name]; !ok {
+				known[s.Name] = s
+			}
+		}
+	}
+	for _, s := range schemas {
+		for name, f := range s.Fields {
+			if f.References == nil || f.References.Table == s.Name {
+				continue
+			}
+			if err := ValidateReferences(name, f, known); err != nil {
+				errs = append(errs, fmt.Errorf("table %q: %w", s.Name, err))
+			}
+		}
+		if err := ValidateRelationships(s, known); err != nil {
+			errs = append(errs, err)
+		}
+		if err := ValidateForeignKeys(s, known); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return schemas, nil
+}
+
+// LoadSchema reads the file at path, which may hold a single table or a
+// multi-table Database, and returns the named table after running a
+// complete validation pass over its containing file.
+func LoadSchema(path string) (TableSchema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return TableSchema{}, fmt.Errorf("%s: %w", path, ErrNotFound)
+		}
+		return TableSchema{}, err
+	}
+	schemas, err := validatedSchemas(data, filepath.Ext(path))
+	if err != nil {
+		return TableSchema{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if len(schemas) == 1 {
+		return schemas[0], nil
+	}
+	table := filepath.Base(path)
+	table = table[:len(table)-len(filepath.Ext(table))]
+	for _, s := range schemas {
+		if s.Name == table {
+			return s, nil
+		}
+	}
+	return TableSchema{}, fmt.Errorf("%s: contains %d tables, none named %q", path, len(schemas), table)
+}
+
+// LoadSchemaByName finds table by name, whether it lives in its own
+// <table>.toml file or as an entry in a multi-table Database file
+// somewhere in Dir, and validates it the same way LoadSchema does.
+func LoadSchemaByName(table string) (TableSchema, error) {
+	for _, ext := range extensions {
+		path := filepath.Join(Dir, table+ext)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		schemas, err := validatedSchemas(data, ext)
+		if err != nil {
+			return TableSchema{}, fmt.Errorf("load table %q: %w", table, err)
+		}
+		for _, s := range schemas {
+			if s.Name == table {
+				return s, nil
+			}
+		}
+	}
+
+	entries, err := os.ReadDir(Dir)
+	if err != nil {
+		return TableSchema{}, fmt.Errorf("load table %q: %w", table, err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !IsSchemaFile(entry.Name()) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(Dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		schemas, err := validatedSchemas(data, filepath.Ext(entry.Name()))
+		if err != nil {
+			return TableSchema{}, fmt.Errorf("load table %q: %w", table, err)
+		}
+		for _, s := range schemas {
+			if s.Name == table {
+				return s, nil
+			}
+		}
+	}
+	return TableSchema{}, fmt.Errorf("load table %q: not found in %s: %w", table, Dir, ErrNotFound)
+}
+
+// LoadSchemaFile finds table by name the same way LoadSchemaByName does,
+// without running full validation — callers that mutate a schema run
+// Validate themselves before writing it back.
+func LoadSchemaFile(table string) (TableSchema, error) {
+	for _, ext := range extensions {
+		data, err := os.ReadFile(filepath.Join(Dir, table+ext))
+		if err != nil {
+			continue
+		}
+		schemas, err := SchemasFromData(data, ext)
+		if err != nil {
+			return TableSchema{}, fmt.Errorf("load table %q: %w", table, err)
+		}
+		for _, s := range schemas {
+			if s.Name == table {
+				return s, nil
+			}
+		}
+	}
+
+	schemas, err := AllSchemas()
+	if err != nil {
+		return TableSchema{}, fmt.Errorf("load table %q: %w", table, err)
+	}
+	for _, s := range schemas {
+		if s.Name == table {
+			return s, nil
+		}
+	}
+	return TableSchema{}, fmt.Errorf("load table %q: not found in %s: %w", table, Dir, ErrNotFound)
+}
+
+// AllSchemas returns every TableSchema found across every schema file in
+// Dir (toml, json, or yaml), flattening multi-table Database files
+// transparently.
+func AllSchemas() ([]TableSchema, error) {
+	entries, err := os.ReadDir(Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var schemas []TableSchema
+	for _, entry := range entries {
+		if entry.IsDir() || !IsSchemaFile(entry.Name()) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(Dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		fileSchemas, err := SchemasFromData(data, filepath.Ext(entry.Name()))
+		if err != nil {
+			continue
+		}
+		schemas = append(schemas, fileSchemas...)
+	}
+	return schemas, nil
+}
+
+// FindDependents returns the names of tables in Dir whose fields or
+// ForeignKeys reference table, so a drop can refuse to break them.
+func FindDependents(table string) ([]string, error) {
+	schemas, err := AllSchemas()
+	if err != nil {
+		return nil, err
+	}
+
+	var dependents []string
+	for _, s := range schemas {
+		if s.Name == table {
+			continue
+		}
+		dependent := false
+		for _, f := range s.Fields {
+			if f.References != nil && f.References.Table == table {
+				dependent = true
+				break
+			}
+		}
+		if !dependent {
+			for _, fk := range s.ForeignKeys {
+				if fk.RefTable == table {
+					dependent = true
+					break
+				}
+			}
+		}
+		if dependent {
+			dependents = append(dependents, s.Name)
+		}
+	}
+	return dependents, nil
+}
+
+// FieldReferrers returns "table.field" strings for every field in Dir
+// that References the given table+field, plus "table" for every
+// table-level ForeignKey that includes it among its RefColumns.
+func FieldReferrers(table, field string) ([]string, error) {
+	schemas, err := AllSchemas()
+	if err != nil {
+		return nil, err
+	}
+
+	var referrers []string
+	for _, s := range schemas {
+		for fieldName, f := range s.Fields {
+			if f.References != nil && f.References.Table == table && f.References.Field == field {
+				referrers = append(referrers, fmt.Sprintf("%s.%s", s.Name, fieldName))
+			}
+		}
+		for _, fk := range s.ForeignKeys {
+			if fk.RefTable != table {
+				continue
+			}
+			for _, refName := range fk.RefColumns {
+				if refName == field {
+					referrers = append(referrers, s.Name)
+					break
+				}
+			}
+		}
+	}
+	return referrers, nil
+}
+
+// MarshalSchema encodes s as toml, json, or yaml. An unrecognized format
+// is an error rather than a silent fallback to toml.
+func MarshalSchema(s TableSchema, format string) ([]byte, error) {
+	switch format {
+	case "", "toml":
+		return toml.Marshal(s)
+	case "json":
+		return json.MarshalIndent(s, "", "  ")
+	case "yaml":
+		return yaml.Marshal(s)
+	default:
+		return nil, fmt.Errorf("unknown format %q, expected toml, json, or yaml", format)
+	}
+}
+
+// FormatExt maps a --format value to its file extension, defaulting to
+// toml for an empty format.
+func FormatExt(format string) string {
+	switch format {
+	case "json":
+		return ".json"
+	case "yaml":
+		return ".yaml"
+	default:
+		return ".toml"
+	}
+}
+
+// VerifyRoundTrip marshals s, unmarshals the result back into a
+// TableSchema, and re-marshals that: both the bytes and the decoded
+// schema must come out identical to s, or a tool-written file would
+// silently diverge from what a hand edit sees on the next load.
+func VerifyRoundTrip(s TableSchema, format string) error {
+	data, err := MarshalSchema(s, format)
+	if err != nil {
+		return fmt.Errorf("round-trip table %q: %w", s.Name, err)
+	}
+
+	var roundTripped TableSchema
+	if err := unmarshalByExt(data, FormatExt(format), &roundTripped); err != nil {
+		return fmt.Errorf("round-trip table %q: %w", s.Name, err)
+	}
+	if !s.Equal(roundTripped) {
+		return fmt.Errorf("round-trip table %q: unmarshaling produced a different schema", s.Name)
+	}
+
+	data2, err := MarshalSchema(roundTripped, format)
+	if err != nil {
+		return fmt.Errorf("round-trip table %q: %w", s.Name, err)
+	}
+	if !bytes.Equal(data, data2) {
+		return fmt.Errorf("round-trip table %q: re-marshaling produced different bytes", s.Name)
+	}
+	return nil
+}
+
+// SaveSchemaFile writes s to its default Path under Dir as toml, after
+// verifying it round-trips. Callers that need overwrite confirmation or a
+// non-default path/format should marshal and write the file themselves.
+func SaveSchemaFile(s TableSchema) error {
+	if err := VerifyRoundTrip(s, "toml"); err != nil {
+		return fmt.Errorf("save table %q: %w", s.Name, err)
+	}
+	data, err := toml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("save table %q: %w", s.Name, err)
+	}
+	if err := os.MkdirAll(Dir, 0o755); err != nil {
+		return fmt.Errorf("save table %q: %w", s.Name, err)
+	}
+	return os.WriteFile(Path(s.Name), data, 0o644)
+}
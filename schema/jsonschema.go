@@ -0,0 +1,86 @@
+package schema
+
+import (
+	"fmt"
+)
+
+// jsonSchemaType renders f's Draft-07 JSON Schema representation by
+// delegating to f.DataType's registered DataTypeSpec, so a custom type's
+// mapping works here without this function knowing about it.
+func jsonSchemaType(f TableField) (map[string]any, error) {
+	spec, ok := dataTypeRegistry[string(f.DataType)]
+	if !ok || spec.JSONSchema == nil {
+		return nil, fmt.Errorf("no JSON Schema mapping for DataType %q", f.DataType)
+	}
+	return spec.JSONSchema(f)
+}
+
+func addNumericRange(schema map[string]any, f TableField) {
+	if f.Min != nil {
+		schema["minimum"] = *f.Min
+	}
+	if f.Max != nil {
+		schema["maximum"] = *f.Max
+	}
+}
+
+// addNullable widens a "type" string into a ["type", "null"] array when f
+// is nullable, matching DDL's NOT NULL/nullable distinction. Schemas with
+// no "type" key (e.g. DataTypeJSON's {}) are left alone.
+func addNullable(schema map[string]any, f TableField) {
+	if !f.Nullable() {
+		return
+	}
+	t, ok := schema["type"].(string)
+	if !ok {
+		return
+	}
+	schema["type"] = []any{t, "null"}
+}
+
+// ToJSONSchema renders s as a Draft-07 JSON Schema object.
+func (s TableSchema) ToJSONSchema() (map[string]any, error) {
+	properties := map[string]any{}
+	var required []string
+
+	names := s.FieldOrder()
+
+	for _, name := range names {
+		f := s.Fields[name]
+		prop, err := jsonSchemaType(f)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", name, err)
+		}
+		addNullable(prop, f)
+		if f.Comment != "" {
+			prop["description"] = f.Comment
+		}
+		if len(f.Tags) > 0 {
+			prop["x-tags"] = f.Tags
+		}
+		if f.Encrypted {
+			prop["x-encrypted"] = true
+			if f.EncryptionKeyRef != "" {
+				prop["x-encryption-key-ref"] = f.EncryptionKeyRef
+			}
+		}
+		properties[name] = prop
+		if !f.Nullable() {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{
+		"$schema":    "http://json-schema.org/draft-07/schema#",
+		"title":      s.Name,
+		"type":       "object",
+		"properties": properties,
+	}
+	if s.Comment != "" {
+		schema["description"] = s.Comment
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema, nil
+}
@@ -0,0 +1,135 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// LintSeverity distinguishes a lint finding a team might want to fix
+// eventually (LintWarning) from one that should fail CI (LintError).
+type LintSeverity string
+
+const (
+	LintWarning LintSeverity = "warning"
+	LintError   LintSeverity = "error"
+)
+
+// LintFinding describes one rule violation found by running LintRules
+// against a TableSchema.
+type LintFinding struct {
+	Rule     string
+	Severity LintSeverity
+	Table    string
+	Field    string
+	Message  string
+}
+
+// String renders a LintFinding as a one-line human summary.
+func (f LintFinding) String() string {
+	loc := f.Table
+	if f.Field != "" {
+		loc += "." + f.Field
+	}
+	return fmt.Sprintf("%s [%s] %s: %s", f.Severity, f.Rule, loc, f.Message)
+}
+
+// LintRules is the registry of built-in lint checks. Each entry's Name is
+// the Rule field stamped onto its findings, and the key a caller uses to
+// enable or disable it.
+var LintRules = []struct {
+	Name  string
+	Check func(TableSchema) []LintFinding
+}{
+	{"primary_key", lintPrimaryKey},
+	{"timestamptz", lintTimestampType},
+	{"fk_suffix", lintForeignKeySuffix},
+	{"table_name_style", lintTableNameStyle},
+	{"pii_encrypted", lintPIIEncrypted},
+	{"updated_at_immutable", lintUpdatedAtImmutable},
+}
+
+// lintPrimaryKey flags a table whose effective primary key — explicit
+// PrimaryKey, or the implicit "id" field — doesn't exist or isn't
+// required. Validate already rejects this, but lint can run ahead of a
+// full validation pass and states it as a style rule a team can silence.
+func lintPrimaryKey(s TableSchema) []LintFinding {
+	if s.IsView() {
+		return nil
+	}
+	pk := s.PrimaryKey
+	if len(pk) == 0 {
+		pk = []string{"id"}
+	}
+	for _, name := range pk {
+		f, ok := s.Fields[name]
+		if !ok || !f.Required {
+			return []LintFinding{{Rule: "primary_key", Severity: LintError, Table: s.Name, Message: "no primary key: set primary_key or add a required \"id\" field"}}
+		}
+	}
+	return nil
+}
+
+// lintTimestampType flags timestamp fields that dropped the timezone,
+// which silently stores wall-clock time instead of an instant.
+func lintTimestampType(s TableSchema) []LintFinding {
+	var findings []LintFinding
+	for _, name := range s.FieldOrder() {
+		if s.Fields[name].DataType == DataTypeTimestamp {
+			findings = append(findings, LintFinding{Rule: "timestamptz", Severity: LintWarning, Table: s.Name, Field: name, Message: "timestamp has no timezone, prefer timestamptz"})
+		}
+	}
+	return findings
+}
+
+// lintForeignKeySuffix flags a foreign key field whose name doesn't end
+// in "_id", the convention every hand-written schema in this repo follows.
+func lintForeignKeySuffix(s TableSchema) []LintFinding {
+	var findings []LintFinding
+	for _, name := range s.FieldOrder() {
+		if f := s.Fields[name]; f.References != nil && !strings.HasSuffix(name, "_id") {
+			findings = append(findings, LintFinding{Rule: "fk_suffix", Severity: LintWarning, Table: s.Name, Field: name, Message: "foreign key field should end in \"_id\""})
+		}
+	}
+	return findings
+}
+
+var snakeCaseRe = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// lintTableNameStyle flags a table name that isn't snake_case, or that
+// doesn't look plural, since a table holds a collection of rows.
+func lintTableNameStyle(s TableSchema) []LintFinding {
+	if !snakeCaseRe.MatchString(s.Name) {
+		return []LintFinding{{Rule: "table_name_style", Severity: LintWarning, Table: s.Name, Message: "table name should be snake_case"}}
+	}
+	if !strings.HasSuffix(s.Name, "s") {
+		return []LintFinding{{Rule: "table_name_style", Severity: LintWarning, Table: s.Name, Message: "table name should be plural"}}
+	}
+	return nil
+}
+
+// lintPIIEncrypted flags a field tagged pii=true that isn't marked
+// Encrypted, since that combination is the usual compliance gap this tag
+// exists to catch.
+func lintPIIEncrypted(s TableSchema) []LintFinding {
+	var findings []LintFinding
+	for _, name := range s.FieldOrder() {
+		f := s.Fields[name]
+		if f.Tags["pii"] == "true" && !f.Encrypted {
+			findings = append(findings, LintFinding{Rule: "pii_encrypted", Severity: LintWarning, Table: s.Name, Field: name, Message: "tagged pii but not encrypted"})
+		}
+	}
+	return findings
+}
+
+// lintUpdatedAtImmutable flags an updated_at field marked Immutable,
+// since OnUpdate and Immutable contradict each other: one says the
+// field's value is refreshed on every update, the other rejects updates
+// to it outright.
+func lintUpdatedAtImmutable(s TableSchema) []LintFinding {
+	f, ok := s.Fields["updated_at"]
+	if !ok || !f.Immutable {
+		return nil
+	}
+	return []LintFinding{{Rule: "updated_at_immutable", Severity: LintWarning, Table: s.Name, Field: "updated_at", Message: "marked immutable, so it can never actually be updated"}}
+}
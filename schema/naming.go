@@ -0,0 +1,151 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// namingConventions maps a convention name to the pattern a conforming
+// identifier must match. Like identifierRe, these are deliberately
+// case/underscore-based rather than word-aware: an acronym embedded in an
+// identifier (userID, http_url) doesn't trip either pattern, since the
+// convention only cares about the boundary characters, not what's between
+// them.
+var namingConventions = map[string]*regexp.Regexp{
+	"snake_case": regexp.MustCompile(`^[a-z][a-z0-9_]*$`),
+	"camelCase":  regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`),
+}
+
+// NamingViolation is a table or field name that doesn't match the
+// convention CheckNames was asked to enforce.
+type NamingViolation struct {
+	Location  string `json:"location"`
+	Name      string `json:"name"`
+	Suggested string `json:"suggested"`
+}
+
+// String renders a violation as "location: \"name\" should be \"suggested\"".
+func (v NamingViolation) String() string {
+	return fmt.Sprintf("%s: %q should be %q", v.Location, v.Name, v.Suggested)
+}
+
+// CheckNames reports every table and field name across schemas that
+// doesn't match convention, alongside a suggested replacement. Automatic
+// fields (id, created_at, and the like) are skipped, since their names
+// come from the tool itself rather than the project.
+func CheckNames(schemas []TableSchema, convention string) ([]NamingViolation, error) {
+	pattern, ok := namingConventions[convention]
+	if !ok {
+		return nil, fmt.Errorf("check names: unsupported convention %q, expected snake_case or camelCase", convention)
+	}
+
+	var violations []NamingViolation
+	for _, s := range schemas {
+		if !pattern.MatchString(s.Name) {
+			violations = append(violations, NamingViolation{
+				Location:  s.Name,
+				Name:      s.Name,
+				Suggested: ConvertToConvention(s.Name, convention),
+			})
+		}
+
+		names := make([]string, 0, len(s.Fields))
+		for name := range s.Fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if s.Fields[name].Automatic {
+				continue
+			}
+			if !pattern.MatchString(name) {
+				violations = append(violations, NamingViolation{
+					Location:  s.Name + "." + name,
+					Name:      name,
+					Suggested: ConvertToConvention(name, convention),
+				})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// ConvertToConvention rewrites name to follow convention, splitting it
+// into words first so that mixed separators (snake_case, camelCase, or a
+// combination left over from a previous rename) all land on the same
+// result.
+func ConvertToConvention(name, convention string) string {
+	words := splitWords(name)
+	if len(words) == 0 {
+		return name
+	}
+
+	switch convention {
+	case "snake_case":
+		return strings.ToLower(strings.Join(words, "_"))
+	case "camelCase":
+		var b strings.Builder
+		for i, word := range words {
+			lower := strings.ToLower(word)
+			if i == 0 {
+				b.WriteString(lower)
+				continue
+			}
+			b.WriteString(strings.ToUpper(lower[:1]))
+			b.WriteString(lower[1:])
+		}
+		return b.String()
+	default:
+		return name
+	}
+}
+
+// splitWords breaks an identifier into words on underscores and
+// camelCase/acronym boundaries, e.g. "userID" -> ["user", "ID"] and
+// "http_url" -> ["http", "url"], so ConvertToConvention can reassemble it
+// under a different convention without mangling acronyms.
+func splitWords(name string) []string {
+	var words []string
+	var current []rune
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+	}
+
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-':
+			flush()
+		case r >= 'A' && r <= 'Z':
+			prev := rune(0)
+			if i > 0 {
+				prev = runes[i-1]
+			}
+			next := rune(0)
+			if i+1 < len(runes) {
+				next = runes[i+1]
+			}
+			// Start a new word at a lower-to-upper boundary (userID) or at
+			// the last letter of a run of capitals before a new word
+			// (HTTPServer -> HTTP, Server), but not in the middle of an
+			// acronym (HTTP).
+			startsNewWord := prev != 0 && prev >= 'a' && prev <= 'z'
+			startsNewWord = startsNewWord || (prev != 0 && prev >= 'A' && prev <= 'Z' && next >= 'a' && next <= 'z')
+			if startsNewWord {
+				flush()
+			}
+			current = append(current, r)
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+	return words
+}
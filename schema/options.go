@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// postgresKnownOptions are the TableSchema.Options keys ToPostgresDDL
+// actually renders, either into the WITH (...) storage clause or as a
+// TABLESPACE clause.
+var postgresKnownOptions = map[string]bool{
+	"fillfactor":                      true,
+	"tablespace":                      true,
+	"autovacuum_enabled":              true,
+	"autovacuum_vacuum_scale_factor":  true,
+	"autovacuum_analyze_scale_factor": true,
+	"fastupdate":                      true,
+}
+
+// sqliteKnownOptions is empty: ToSQLiteDDL doesn't render any storage
+// option, so every key is unknown to it.
+var sqliteKnownOptions = map[string]bool{}
+
+// UnknownTableOptions returns the sorted keys of s.Options that dialect's
+// DDL generation doesn't recognize, so a caller can warn about a likely
+// typo or an option that simply won't take effect for that dialect.
+// Validate itself leaves Options opaque; this is the non-fatal check a
+// dialect-aware command runs instead.
+func UnknownTableOptions(s TableSchema, dialect string) ([]string, error) {
+	var known map[string]bool
+	switch dialect {
+	case "postgres":
+		known = postgresKnownOptions
+	case "sqlite":
+		known = sqliteKnownOptions
+	default:
+		return nil, fmt.Errorf("unknown dialect %q", dialect)
+	}
+
+	var unknown []string
+	for k := range s.Options {
+		if !known[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown, nil
+}
@@ -0,0 +1,89 @@
+package schema
+
+import "fmt"
+
+// RedactAbove returns a copy of s with every field whose Sensitivity
+// exceeds level removed, along with any PrimaryKey, Unique, Indexes,
+// ForeignKeys, or OrderBy entry that referenced one of those fields — so
+// the result doesn't point at columns that no longer exist. It's meant
+// for producing a shareable export that omits sensitive columns while the
+// original schema file stays the canonical source.
+func RedactAbove(s TableSchema, level string) (TableSchema, error) {
+	threshold, ok := sensitivityLevels[level]
+	if !ok {
+		return TableSchema{}, fmt.Errorf("redact-above: unsupported sensitivity level %q, expected public, internal, confidential, or pii", level)
+	}
+
+	removed := map[string]bool{}
+	fields := make(map[string]TableField, len(s.Fields))
+	for name, f := range s.Fields {
+		if sensitivityLevels[f.EffectiveSensitivity()] > threshold {
+			removed[name] = true
+			continue
+		}
+		fields[name] = f
+	}
+
+	out := s
+	out.Fields = fields
+	out.Order = filterNames(s.Order, removed)
+	out.PrimaryKey = filterNames(s.PrimaryKey, removed)
+
+	out.Unique = nil
+	for _, group := range s.Unique {
+		if anyRemoved(group, removed) {
+			continue
+		}
+		out.Unique = append(out.Unique, group)
+	}
+
+	out.Indexes = nil
+	for _, idx := range s.Indexes {
+		if anyRemoved(idx.Fields, removed) {
+			continue
+		}
+		out.Indexes = append(out.Indexes, idx)
+	}
+
+	out.ForeignKeys = nil
+	for _, fk := range s.ForeignKeys {
+		if anyRemoved(fk.Columns, removed) {
+			continue
+		}
+		out.ForeignKeys = append(out.ForeignKeys, fk)
+	}
+
+	out.OrderBy = nil
+	for _, clause := range s.OrderBy {
+		if removed[clause.Field] {
+			continue
+		}
+		out.OrderBy = append(out.OrderBy, clause)
+	}
+
+	return out, nil
+}
+
+// filterNames returns names with every entry in removed dropped.
+func filterNames(names []string, removed map[string]bool) []string {
+	if len(names) == 0 {
+		return names
+	}
+	var kept []string
+	for _, name := range names {
+		if !removed[name] {
+			kept = append(kept, name)
+		}
+	}
+	return kept
+}
+
+// anyRemoved reports whether any of names is in removed.
+func anyRemoved(names []string, removed map[string]bool) bool {
+	for _, name := range names {
+		if removed[name] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FileReport is one schema file's pass/fail result from ValidateAllFiles.
+type FileReport struct {
+	File   string   `json:"file"`
+	OK     bool     `json:"ok"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ValidateAllFiles runs LoadSchema against every schema file in Dir and
+// reports each one's outcome independently, unlike AllSchemas, which
+// silently skips a file that fails to read or parse. A file's errors are
+// split on newline into one entry per underlying problem, since both
+// Validate and LoadSchema join multiple failures with errors.Join, which
+// renders one line per error.
+func ValidateAllFiles() ([]FileReport, error) {
+	entries, err := os.ReadDir(Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var reports []FileReport
+	for _, entry := range entries {
+		if entry.IsDir() || !IsSchemaFile(entry.Name()) {
+			continue
+		}
+		report := FileReport{File: entry.Name(), OK: true}
+		if _, err := LoadSchema(filepath.Join(Dir, entry.Name())); err != nil {
+			report.OK = false
+			report.Errors = strings.Split(err.Error(), "\n")
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].File < reports[j].File })
+	return reports, nil
+}
@@ -0,0 +1,634 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+// DataType identifies the kind of value a field holds.
+type DataType string
+
+const (
+	DataTypeUUID        DataType = "uuid"
+	DataTypeTimestamp   DataType = "timestamp"
+	DataTypeString      DataType = "string"
+	DataTypeInt32       DataType = "int32"
+	DataTypeInt64       DataType = "int64"
+	DataTypeBool        DataType = "bool"
+	DataTypeFloat32     DataType = "float32"
+	DataTypeFloat64     DataType = "float64"
+	DataTypeDecimal     DataType = "decimal"
+	DataTypeJSON        DataType = "json"
+	DataTypeEnum        DataType = "enum"
+	DataTypeBytes       DataType = "bytes"
+	DataTypeDate        DataType = "date"
+	DataTypeTime        DataType = "time"
+	DataTypeTimestampTZ DataType = "timestamptz"
+	DataTypeArray       DataType = "array"
+)
+
+// Valid reports whether d is registered, built-in or custom.
+func (d DataType) Valid() bool {
+	_, ok := dataTypeRegistry[string(d)]
+	return ok
+}
+
+// ParseDataType parses s into a registered DataType, or returns an error
+// suggesting the closest registered name if s isn't one.
+func ParseDataType(s string) (DataType, error) {
+	d := DataType(s)
+	if d.Valid() {
+		return d, nil
+	}
+
+	names := RegisteredDataTypeNames()
+	closest := names[0]
+	best := levenshtein(s, closest)
+	for _, known := range names[1:] {
+		if dist := levenshtein(s, known); dist < best {
+			best, closest = dist, known
+		}
+	}
+	return "", fmt.Errorf("unknown data type %q, did you mean %q?", s, closest)
+}
+
+// levenshtein computes the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// IsTemporal reports whether this type represents a date and/or time
+// value, per its registered DataTypeSpec.
+func (d DataType) IsTemporal() bool {
+	spec, ok := dataTypeRegistry[string(d)]
+	return ok && spec.Temporal
+}
+
+// IsBinary reports whether this type holds raw binary data, per its
+// registered DataTypeSpec.
+func (d DataType) IsBinary() bool {
+	spec, ok := dataTypeRegistry[string(d)]
+	return ok && spec.Binary
+}
+
+// IsNumeric reports whether values of this type support arithmetic
+// comparisons, per its registered DataTypeSpec, so constraint logic can
+// branch on it without enumerating every numeric kind.
+func (d DataType) IsNumeric() bool {
+	spec, ok := dataTypeRegistry[string(d)]
+	return ok && spec.Numeric
+}
+
+// TableSchema is the full description of a table, keyed by field name.
+// Struct tags cover toml, json, and yaml so the same field names survive
+// whichever serializer a schema file was written with.
+type TableSchema struct {
+	Id     uuid.UUID             `toml:"id" json:"id" yaml:"id"`
+	Name   string                `toml:"name" json:"name" yaml:"name"`
+	Fields map[string]TableField `toml:"fields" json:"fields" yaml:"fields"`
+
+	// Order names every field in Fields, in the sequence they should be
+	// displayed and rendered into DDL. Fields loaded without one fall back
+	// to alphabetical order; any field present in Fields but missing from
+	// Order is appended, alphabetically, after the named ones.
+	Order []string `toml:"order,omitempty" json:"order,omitempty" yaml:"order,omitempty"`
+
+	// Version counts how many times add/remove field has rewritten this
+	// file. Schemas loaded without one default to 1.
+	Version int `toml:"version,omitempty" json:"version,omitempty" yaml:"version,omitempty"`
+
+	// PrimaryKey names the fields, in order, that together form the
+	// table's primary key. When empty, "id" is the implicit single-column
+	// primary key.
+	PrimaryKey []string `toml:"primary_key,omitempty" json:"primary_key,omitempty" yaml:"primary_key,omitempty"`
+
+	Indexes []Index `toml:"indexes,omitempty" json:"indexes,omitempty" yaml:"indexes,omitempty"`
+
+	// Unique lists groups of field names that must be jointly unique,
+	// each rendered as its own UNIQUE (...) table constraint. Unlike
+	// Indexes, these don't need a name or support a partial WHERE — for
+	// that, use an Index with Unique set instead.
+	Unique [][]string `toml:"unique,omitempty" json:"unique,omitempty" yaml:"unique,omitempty"`
+
+	// Checks are table-level CHECK constraints that may span multiple
+	// columns, e.g. "start_date < end_date". Unlike TableField.Check,
+	// these aren't tied to a single field. The expression is stored
+	// opaque: it's emitted into DDL as-is, never parsed or evaluated.
+	Checks []TableCheck `toml:"checks,omitempty" json:"checks,omitempty" yaml:"checks,omitempty"`
+
+	// ForeignKeys lists multi-column foreign keys, for a reference into a
+	// composite primary or unique key that a single TableField.References
+	// can't express. A single-column reference should still use
+	// TableField.References; this is only for the composite case.
+	ForeignKeys []ForeignKey `toml:"foreign_keys,omitempty" json:"foreign_keys,omitempty" yaml:"foreign_keys,omitempty"`
+
+	// Relationships models higher-level associations between tables, on
+	// top of the low-level References a field may carry. This is what an
+	// ERD generator or join-table scaffolder would read instead of
+	// inferring structure from foreign keys.
+	Relationships []Relationship `toml:"relationships,omitempty" json:"relationships,omitempty" yaml:"relationships,omitempty"`
+
+	// Seeds are default rows to ship with the table, e.g. for a lookup or
+	// enum table. Each map's keys must name real fields.
+	Seeds []map[string]any `toml:"seeds,omitempty" json:"seeds,omitempty" yaml:"seeds,omitempty"`
+
+	// Comment is a free-form description of the table, surfaced as a
+	// COMMENT ON TABLE in DDL and as the JSON Schema description.
+	Comment string `toml:"comment,omitempty" json:"comment,omitempty" yaml:"comment,omitempty"`
+
+	// Namespace is the Postgres schema this table lives in, e.g. "billing"
+	// for billing.invoices. Empty means "public".
+	Namespace string `toml:"namespace,omitempty" json:"namespace,omitempty" yaml:"namespace,omitempty"`
+
+	// Partition declares this table as partitioned, rendered as a
+	// PARTITION BY clause on CREATE TABLE. Individual partitions aren't
+	// managed here — this only affects the parent table's DDL.
+	Partition *Partition `toml:"partition,omitempty" json:"partition,omitempty" yaml:"partition,omitempty"`
+
+	// Options carries dialect-specific storage/engine tuning, e.g.
+	// fillfactor or tablespace for Postgres. The map is kept opaque by
+	// Validate — it's passed through to DDL generation as-is, and a key
+	// a dialect doesn't render is reported by UnknownTableOptions rather
+	// than rejected outright.
+	Options map[string]string `toml:"options,omitempty" json:"options,omitempty" yaml:"options,omitempty"`
+
+	// Kind distinguishes a regular table from a view. Empty means
+	// TableKindTable.
+	Kind TableKind `toml:"kind,omitempty" json:"kind,omitempty" yaml:"kind,omitempty"`
+
+	// Query holds a view's SELECT statement. Required when Kind is
+	// TableKindView, and meaningless otherwise — a view's Fields still
+	// describe its output columns, but DDL generation emits CREATE VIEW
+	// ... AS Query instead of a CREATE TABLE built from them.
+	Query string `toml:"query,omitempty" json:"query,omitempty" yaml:"query,omitempty"`
+
+	// OrderBy is this table's natural display order, e.g. "created_at
+	// desc" for a feed. It's metadata for query-generation tooling and
+	// describe output, not enforced or emitted in DDL — a SELECT has no
+	// inherent order until a caller adds its own ORDER BY.
+	OrderBy []OrderClause `toml:"order_by,omitempty" json:"order_by,omitempty" yaml:"order_by,omitempty"`
+}
+
+// TableKind distinguishes a regular table from a view.
+type TableKind string
+
+const (
+	TableKindTable TableKind = "table"
+	TableKindView  TableKind = "view"
+)
+
+// tableKinds are the TableKind values TableSchema.Kind accepts.
+var tableKinds = map[TableKind]bool{
+	TableKindTable: true,
+	TableKindView:  true,
+}
+
+// EffectiveKind returns s.Kind, defaulting to TableKindTable when unset.
+func (s TableSchema) EffectiveKind() TableKind {
+	if s.Kind == "" {
+		return TableKindTable
+	}
+	return s.Kind
+}
+
+// IsView reports whether s is a view rather than a regular table.
+func (s TableSchema) IsView() bool {
+	return s.EffectiveKind() == TableKindView
+}
+
+// PartitionStrategy selects how a partitioned table's rows are routed to
+// partitions.
+type PartitionStrategy string
+
+const (
+	PartitionRange PartitionStrategy = "range"
+	PartitionList  PartitionStrategy = "list"
+	PartitionHash  PartitionStrategy = "hash"
+)
+
+// partitionStrategies are the PartitionStrategy values Partition.Strategy
+// accepts.
+var partitionStrategies = map[PartitionStrategy]bool{
+	PartitionRange: true,
+	PartitionList:  true,
+	PartitionHash:  true,
+}
+
+// Partition is a table's PARTITION BY declaration: Strategy names the
+// partitioning method and Fields the column(s) it partitions on.
+type Partition struct {
+	Strategy PartitionStrategy `toml:"strategy" json:"strategy" yaml:"strategy"`
+	Fields   []string          `toml:"fields" json:"fields" yaml:"fields"`
+}
+
+// EffectiveNamespace returns s.Namespace, defaulting to "public" when unset.
+func (s TableSchema) EffectiveNamespace() string {
+	if s.Namespace == "" {
+		return "public"
+	}
+	return s.Namespace
+}
+
+// RelationshipKind identifies the cardinality of a Relationship.
+type RelationshipKind string
+
+const (
+	RelationshipOneToOne   RelationshipKind = "one-to-one"
+	RelationshipOneToMany  RelationshipKind = "one-to-many"
+	RelationshipManyToMany RelationshipKind = "many-to-many"
+)
+
+// Relationship describes an association from its owning table to Target,
+// independent of which column carries the foreign key. Through names the
+// join table for a many-to-many relationship.
+type Relationship struct {
+	Name    string           `toml:"name" json:"name" yaml:"name"`
+	Kind    RelationshipKind `toml:"kind" json:"kind" yaml:"kind"`
+	Target  string           `toml:"target" json:"target" yaml:"target"`
+	Through string           `toml:"through,omitempty" json:"through,omitempty" yaml:"through,omitempty"`
+}
+
+// Database is a container for multiple TableSchemas in a single file, for
+// projects that'd rather keep related tables together. A table's key in
+// the map is its name; TableSchema.Name is also set so callers that
+// flatten a Database into a []TableSchema don't lose it.
+type Database struct {
+	Tables map[string]TableSchema `toml:"tables" json:"tables" yaml:"tables"`
+}
+
+// Index describes a database index over one or more fields.
+type Index struct {
+	Name   string   `toml:"name" json:"name" yaml:"name"`
+	Fields []string `toml:"fields" json:"fields" yaml:"fields"`
+	Unique bool     `toml:"unique,omitempty" json:"unique,omitempty" yaml:"unique,omitempty"`
+
+	// Method selects the Postgres index access method: btree (the
+	// default when empty), hash, gin, or gist.
+	Method string `toml:"method,omitempty" json:"method,omitempty" yaml:"method,omitempty"`
+
+	// Where makes this a partial index, only covering rows matching the
+	// given SQL expression.
+	Where string `toml:"where,omitempty" json:"where,omitempty" yaml:"where,omitempty"`
+}
+
+// OrderClause is one field of a table's default OrderBy, e.g. {Field:
+// "created_at", Desc: true} for "ORDER BY created_at DESC".
+type OrderClause struct {
+	Field string `toml:"field" json:"field" yaml:"field"`
+	Desc  bool   `toml:"desc,omitempty" json:"desc,omitempty" yaml:"desc,omitempty"`
+}
+
+// TableCheck is a named, table-level CHECK constraint, for conditions
+// that relate more than one column and so can't live on a single
+// TableField.
+type TableCheck struct {
+	Name       string `toml:"name" json:"name" yaml:"name"`
+	Expression string `toml:"expression" json:"expression" yaml:"expression"`
+}
+
+// ForeignKey is a table-level, multi-column foreign key, for a reference
+// into a composite primary or unique key that a single
+// TableField.References can't express. Columns and RefColumns must be the
+// same length and are paired positionally.
+type ForeignKey struct {
+	Columns    []string `toml:"columns" json:"columns" yaml:"columns"`
+	RefTable   string   `toml:"ref_table" json:"ref_table" yaml:"ref_table"`
+	RefColumns []string `toml:"ref_columns" json:"ref_columns" yaml:"ref_columns"`
+
+	// Namespace is the referenced table's Postgres schema, for a foreign
+	// key that crosses namespaces. Empty means the referencing table's own
+	// namespace.
+	Namespace string `toml:"namespace,omitempty" json:"namespace,omitempty" yaml:"namespace,omitempty"`
+
+	// OnDelete and OnUpdate select the referential action to take when the
+	// referenced row is deleted or its key is updated, same values as
+	// References.OnDelete/OnUpdate.
+	OnDelete string `toml:"on_delete,omitempty" json:"on_delete,omitempty" yaml:"on_delete,omitempty"`
+	OnUpdate string `toml:"on_update,omitempty" json:"on_update,omitempty" yaml:"on_update,omitempty"`
+}
+
+// indexMethods are the Postgres index access methods Index.Method accepts.
+var indexMethods = map[string]bool{
+	"":      true,
+	"btree": true,
+	"hash":  true,
+	"gin":   true,
+	"gist":  true,
+}
+
+// References describes the table+field a field points at.
+type References struct {
+	Table string `toml:"table" json:"table" yaml:"table"`
+	Field string `toml:"field" json:"field" yaml:"field"`
+
+	// Namespace is the referenced table's Postgres schema, for a foreign
+	// key that crosses namespaces. Empty means the referencing field's own
+	// table's namespace.
+	Namespace string `toml:"namespace,omitempty" json:"namespace,omitempty" yaml:"namespace,omitempty"`
+
+	// OnDelete and OnUpdate select the referential action to take when
+	// the referenced row is deleted or its key is updated: cascade,
+	// restrict, "set null", or "no action" (the default when empty).
+	OnDelete string `toml:"on_delete,omitempty" json:"on_delete,omitempty" yaml:"on_delete,omitempty"`
+	OnUpdate string `toml:"on_update,omitempty" json:"on_update,omitempty" yaml:"on_update,omitempty"`
+}
+
+// referentialActions are the ON DELETE/ON UPDATE actions References.OnDelete
+// and References.OnUpdate accept.
+var referentialActions = map[string]bool{
+	"":          true,
+	"cascade":   true,
+	"restrict":  true,
+	"set null":  true,
+	"no action": true,
+}
+
+// TableField is a single column definition.
+type TableField struct {
+	Id       uuid.UUID `toml:"id" json:"id" yaml:"id"`
+	DataType DataType  `toml:"type" json:"type" yaml:"type"`
+
+	// MaxLength bounds a string field. Zero means unbounded text.
+	MaxLength int `toml:"max_length,omitempty" json:"max_length,omitempty" yaml:"max_length,omitempty"`
+
+	// MaxBytes bounds a bytes field's length. Zero means unbounded.
+	MaxBytes int `toml:"max_bytes,omitempty" json:"max_bytes,omitempty" yaml:"max_bytes,omitempty"`
+
+	// MaxItems bounds an array field's element count. Zero means
+	// unbounded.
+	MaxItems int `toml:"max_items,omitempty" json:"max_items,omitempty" yaml:"max_items,omitempty"`
+
+	// Precision and Scale bound a decimal field, e.g. NUMERIC(p,s).
+	Precision int `toml:"precision,omitempty" json:"precision,omitempty" yaml:"precision,omitempty"`
+	Scale     int `toml:"scale,omitempty" json:"scale,omitempty" yaml:"scale,omitempty"`
+
+	// Values is the allowed-values list for an enum field, preserved in
+	// declaration order. Mutually exclusive with EnumRef: use Values for a
+	// one-off enum, EnumRef to reuse a named one defined in dbexp.toml.
+	Values []string `toml:"values,omitempty" json:"values,omitempty" yaml:"values,omitempty"`
+
+	// EnumRef names a project-level EnumDefinition (dbexp.toml's [[enums]])
+	// this enum field draws its Values from, instead of declaring them
+	// inline. DDL generation emits the referenced enum's CREATE TYPE once
+	// and reuses it across every field/table that references it.
+	EnumRef string `toml:"enum_ref,omitempty" json:"enum_ref,omitempty" yaml:"enum_ref,omitempty"`
+
+	// ElementType is the element type of an array field, e.g. "string" for
+	// string[].
+	ElementType DataType `toml:"element_type,omitempty" json:"element_type,omitempty" yaml:"element_type,omitempty"`
+
+	// References points this field at another table's field, e.g. a
+	// foreign key.
+	References *References `toml:"references,omitempty" json:"references,omitempty" yaml:"references,omitempty"`
+
+	// Default is the field's default value: a literal like "false" for a
+	// boolean column, or, when DefaultExpr is set, a raw SQL expression
+	// like "now()" emitted unquoted instead of type-checked and quoted.
+	Default     string `toml:"default,omitempty" json:"default,omitempty" yaml:"default,omitempty"`
+	DefaultExpr bool   `toml:"default_expr,omitempty" json:"default_expr,omitempty" yaml:"default_expr,omitempty"`
+
+	// Check is a free-form CHECK constraint expression, e.g. "age >= 0".
+	Check string `toml:"check,omitempty" json:"check,omitempty" yaml:"check,omitempty"`
+
+	// Min and Max bound a numeric field. Pointers so an explicit zero is
+	// distinct from "unset".
+	Min *float64 `toml:"min,omitempty" json:"min,omitempty" yaml:"min,omitempty"`
+	Max *float64 `toml:"max,omitempty" json:"max,omitempty" yaml:"max,omitempty"`
+
+	// Pattern is a regex a string field's values must match.
+	Pattern string `toml:"pattern,omitempty" json:"pattern,omitempty" yaml:"pattern,omitempty"`
+
+	Unique    bool `toml:"unique" json:"unique" yaml:"unique"`
+	Required  bool `toml:"required" json:"required" yaml:"required"`
+	Automatic bool `toml:"automatic" json:"automatic" yaml:"automatic"`
+
+	// UniqueNullsNotDistinct changes a nullable Unique field's NULL
+	// handling so multiple NULLs collide as a single duplicate (Postgres
+	// 15+'s UNIQUE NULLS NOT DISTINCT), instead of SQL's default where
+	// NULLs are never considered duplicates of each other. Meaningless
+	// unless Unique is also set, and only a nullable field has NULL
+	// handling to disambiguate in the first place.
+	UniqueNullsNotDistinct bool `toml:"unique_nulls_not_distinct,omitempty" json:"unique_nulls_not_distinct,omitempty" yaml:"unique_nulls_not_distinct,omitempty"`
+
+	// OnUpdate disambiguates an automatic field's semantics: true means
+	// its value is refreshed on every update (e.g. updated_at), false
+	// means it's only ever set at insert time (e.g. created_at, id).
+	OnUpdate bool `toml:"on_update,omitempty" json:"on_update,omitempty" yaml:"on_update,omitempty"`
+
+	// UUIDVersion records which UUID version a uuid field's generated
+	// values use, 4 or 7. Zero means the field isn't a generated uuid, or
+	// predates this setting and should be treated as v4.
+	UUIDVersion int `toml:"uuid_version,omitempty" json:"uuid_version,omitempty" yaml:"uuid_version,omitempty"`
+
+	// TimePrecision sets a temporal field's fractional-second precision
+	// (0-6), e.g. 3 for timestamp(3). Zero means unspecified, leaving the
+	// database's own default precision in place.
+	TimePrecision int `toml:"time_precision,omitempty" json:"time_precision,omitempty" yaml:"time_precision,omitempty"`
+
+	// Generated is the expression for a computed column, e.g.
+	// "first_name || ' ' || last_name". A generated field can't also
+	// carry a Default or be Automatic, since its value always comes from
+	// the expression.
+	Generated string `toml:"generated,omitempty" json:"generated,omitempty" yaml:"generated,omitempty"`
+
+	// Stored says whether a Generated column is materialized on write
+	// (STORED) rather than computed on read (VIRTUAL).
+	Stored bool `toml:"stored,omitempty" json:"stored,omitempty" yaml:"stored,omitempty"`
+
+	// Comment is a free-form description of the field, surfaced as a
+	// COMMENT ON COLUMN in DDL and as the JSON Schema description.
+	Comment string `toml:"comment,omitempty" json:"comment,omitempty" yaml:"comment,omitempty"`
+
+	// AutoIncrement marks an int32/int64 field as a database-assigned
+	// sequential key (SERIAL/BIGSERIAL in Postgres, INTEGER PRIMARY KEY
+	// AUTOINCREMENT in SQLite), for tables that want a serial id instead
+	// of a uuid. Implies Automatic and Required.
+	AutoIncrement bool `toml:"auto_increment,omitempty" json:"auto_increment,omitempty" yaml:"auto_increment,omitempty"`
+
+	// Tags is arbitrary key/value metadata for downstream tooling this
+	// tool doesn't interpret itself, e.g. {"pii": "true"}. It round-trips
+	// and is exempt from validation; JSON Schema export surfaces it under
+	// x-tags.
+	Tags map[string]string `toml:"tags,omitempty" json:"tags,omitempty" yaml:"tags,omitempty"`
+
+	// Encrypted marks a field as encrypted at rest by the application or
+	// database layer. This tool never encrypts or decrypts anything
+	// itself; DDL and JSON Schema exporters surface the annotation as a
+	// comment/x-encrypted extension for whatever does.
+	Encrypted bool `toml:"encrypted,omitempty" json:"encrypted,omitempty" yaml:"encrypted,omitempty"`
+
+	// EncryptionKeyRef optionally names the key or key alias used to
+	// encrypt Encrypted, e.g. a KMS key id. Meaningless unless Encrypted
+	// is set.
+	EncryptionKeyRef string `toml:"encryption_key_ref,omitempty" json:"encryption_key_ref,omitempty" yaml:"encryption_key_ref,omitempty"`
+
+	// Immutable marks a field whose value can never change after insert,
+	// e.g. created_at or a natural key. DDL generation emits a trigger
+	// rejecting updates to it (Postgres).
+	Immutable bool `toml:"immutable,omitempty" json:"immutable,omitempty" yaml:"immutable,omitempty"`
+
+	// Collation names the collation a string/text field's comparisons use,
+	// e.g. "C", "en_US", or an ICU collation name. DDL generation emits it
+	// as COLLATE. Only valid on string-like types.
+	Collation string `toml:"collation,omitempty" json:"collation,omitempty" yaml:"collation,omitempty"`
+
+	// Sensitivity classifies a field for data-governance purposes: public,
+	// internal, confidential, or pii, in increasing order of sensitivity.
+	// Empty means public. RedactAbove uses this to drop fields above a
+	// threshold when producing a shareable export.
+	Sensitivity string `toml:"sensitivity,omitempty" json:"sensitivity,omitempty" yaml:"sensitivity,omitempty"`
+}
+
+// sensitivityLevels orders the Sensitivity values TableField.Sensitivity
+// accepts, from least to most sensitive.
+var sensitivityLevels = map[string]int{
+	"":             0,
+	"public":       0,
+	"internal":     1,
+	"confidential": 2,
+	"pii":          3,
+}
+
+// EffectiveSensitivity returns f.Sensitivity, defaulting to "public" when
+// unset.
+func (f TableField) EffectiveSensitivity() string {
+	if f.Sensitivity == "" {
+		return "public"
+	}
+	return f.Sensitivity
+}
+
+// IsGenerated reports whether the field is a computed column.
+func (f TableField) IsGenerated() bool {
+	return f.Generated != ""
+}
+
+// Nullable reports whether the field's column may hold NULL. An automatic
+// field is never nullable regardless of Required, since the database or
+// application always supplies its value.
+func (f TableField) Nullable() bool {
+	return !f.Required && !f.Automatic
+}
+
+// HasCheck reports whether the field carries a CHECK constraint expression.
+func (f TableField) HasCheck() bool {
+	return f.Check != ""
+}
+
+// FieldOrder returns every name in s.Fields in display order: s.Order's
+// entries that still name a real field, then any remaining fields sorted
+// alphabetically. This is what describe/export/DDL generation iterate
+// over instead of ranging over the map directly.
+func (s TableSchema) FieldOrder() []string {
+	seen := make(map[string]bool, len(s.Fields))
+	order := make([]string, 0, len(s.Fields))
+	for _, name := range s.Order {
+		if _, ok := s.Fields[name]; !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		order = append(order, name)
+	}
+
+	var remaining []string
+	for name := range s.Fields {
+		if !seen[name] {
+			remaining = append(remaining, name)
+		}
+	}
+	sort.Strings(remaining)
+	return append(order, remaining...)
+}
+
+// Equal reports whether s and other are structurally identical, field ids
+// included, so it can tell a genuine edit from a byte-for-byte re-marshal
+// of the same schema. Seeds are compared with normalizeSeeds first: toml,
+// json, and yaml each decode a bare number in a map[string]any to a
+// different Go type (int64, float64, or int respectively), a difference
+// seedLiteral and seedValueMatches already treat as insignificant.
+func (s TableSchema) Equal(other TableSchema) bool {
+	if !reflect.DeepEqual(normalizeSeeds(s.Seeds), normalizeSeeds(other.Seeds)) {
+		return false
+	}
+	s.Seeds, other.Seeds = nil, nil
+	return reflect.DeepEqual(s, other)
+}
+
+// normalizeSeeds returns a copy of seeds with every int/int64/float64 value
+// coerced to float64, so seed rows decoded from different formats compare
+// equal when their values agree.
+func normalizeSeeds(seeds []map[string]any) []map[string]any {
+	if seeds == nil {
+		return nil
+	}
+	normalized := make([]map[string]any, len(seeds))
+	for i, row := range seeds {
+		normalizedRow := make(map[string]any, len(row))
+		for key, value := range row {
+			switch v := value.(type) {
+			case int:
+				normalizedRow[key] = float64(v)
+			case int64:
+				normalizedRow[key] = float64(v)
+			default:
+				normalizedRow[key] = v
+			}
+		}
+		normalized[i] = normalizedRow
+	}
+	return normalized
+}
+
+// HasSoftDelete reports whether s follows the soft-delete convention of a
+// nullable deleted_at timestamp, so DDL and query generation can filter out
+// soft-deleted rows by default.
+func (s TableSchema) HasSoftDelete() bool {
+	f, ok := s.Fields["deleted_at"]
+	return ok && f.DataType == DataTypeTimestampTZ && !f.Required
+}
+
+// IsValidValue reports whether s is one of the field's allowed enum
+// values, inline or resolved via EnumRef. It always returns false for
+// non-enum fields, or an EnumRef that doesn't resolve.
+func (f TableField) IsValidValue(s string) bool {
+	values, err := resolveEnumValues(f)
+	if err != nil {
+		return false
+	}
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
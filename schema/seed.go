@@ -0,0 +1,110 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ValidateSeed checks a single seed row against s's field definitions:
+// every key must name a real field, every required field without a
+// default must be present, and present values must match their field's
+// DataType.
+func ValidateSeed(s TableSchema, row map[string]any) error {
+	var errs []error
+	for key := range row {
+		if _, ok := s.Fields[key]; !ok {
+			errs = append(errs, fmt.Errorf("table %q: seed row references unknown field %q", s.Name, key))
+		}
+	}
+	for name, f := range s.Fields {
+		if f.Automatic || f.Default != "" {
+			continue
+		}
+		if !f.Required {
+			continue
+		}
+		if _, ok := row[name]; !ok {
+			errs = append(errs, fmt.Errorf("table %q: seed row is missing required field %q", s.Name, name))
+		}
+	}
+	for name, value := range row {
+		f, ok := s.Fields[name]
+		if !ok {
+			continue
+		}
+		if err := seedValueMatches(f, value); err != nil {
+			errs = append(errs, fmt.Errorf("table %q: field %q: %w", s.Name, name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func seedValueMatches(f TableField, value any) error {
+	switch f.DataType {
+	case DataTypeString, DataTypeUUID, DataTypeDate, DataTypeTime, DataTypeTimestamp, DataTypeTimestampTZ, DataTypeEnum, DataTypeBytes:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("expected a string, got %T", value)
+		}
+	case DataTypeBool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected a bool, got %T", value)
+		}
+	case DataTypeInt32, DataTypeInt64:
+		switch value.(type) {
+		case int64, int:
+		default:
+			return fmt.Errorf("expected an integer, got %T", value)
+		}
+	case DataTypeFloat32, DataTypeFloat64, DataTypeDecimal:
+		switch value.(type) {
+		case float64, int64, int:
+		default:
+			return fmt.Errorf("expected a number, got %T", value)
+		}
+	}
+	return nil
+}
+
+// SeedInserts renders s.Seeds as Postgres INSERT statements, one per row,
+// with columns in sorted order for determinism. The table is qualified
+// with s.EffectiveNamespace(), the same way toPostgresDDL qualifies its
+// CREATE TABLE target.
+func (s TableSchema) SeedInserts() ([]string, error) {
+	qualifiedName := s.EffectiveNamespace() + "." + s.Name
+	var stmts []string
+	for i, row := range s.Seeds {
+		if err := ValidateSeed(s, row); err != nil {
+			return nil, fmt.Errorf("seed row %d: %w", i, err)
+		}
+
+		columns := make([]string, 0, len(row))
+		for col := range row {
+			columns = append(columns, col)
+		}
+		sort.Strings(columns)
+
+		values := make([]string, len(columns))
+		for j, col := range columns {
+			lit, err := seedLiteral(s.Fields[col], row[col])
+			if err != nil {
+				return nil, fmt.Errorf("seed row %d: field %q: %w", i, col, err)
+			}
+			values[j] = lit
+		}
+		stmts = append(stmts, fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s);", qualifiedName, strings.Join(columns, ", "), strings.Join(values, ", ")))
+	}
+	return stmts, nil
+}
+
+func seedLiteral(f TableField, value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'", nil
+	case bool, int, int64, float64:
+		return fmt.Sprintf("%v", v), nil
+	default:
+		return "", fmt.Errorf("unsupported seed value type %T", value)
+	}
+}
@@ -0,0 +1,89 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+var tsTypeNames = map[DataType]string{
+	DataTypeUUID:        "string",
+	DataTypeTimestamp:   "string",
+	DataTypeTimestampTZ: "string",
+	DataTypeDate:        "string",
+	DataTypeTime:        "string",
+	DataTypeString:      "string",
+	DataTypeInt32:       "number",
+	DataTypeInt64:       "number",
+	DataTypeFloat32:     "number",
+	DataTypeFloat64:     "number",
+	DataTypeDecimal:     "number",
+	DataTypeBool:        "boolean",
+	DataTypeJSON:        "unknown",
+	DataTypeBytes:       "string",
+}
+
+// ToTypeScriptInterface renders s as an exported TypeScript interface with
+// one property per s.FieldOrder(), typed from each field's DataType. A
+// nullable field becomes "field?: T | null" so the client has to handle
+// both "absent" and "explicitly null". The output uses two-space
+// indentation and trailing semicolons, so it needs no reformatting to
+// satisfy a default prettier config.
+func (s TableSchema) ToTypeScriptInterface() (string, error) {
+	names := s.FieldOrder()
+
+	var lines []string
+	for _, name := range names {
+		f := s.Fields[name]
+		tsType, err := tsFieldType(f)
+		if err != nil {
+			return "", fmt.Errorf("field %q: %w", name, err)
+		}
+		if f.Nullable() {
+			lines = append(lines, fmt.Sprintf("  %s?: %s | null;", name, tsType))
+		} else {
+			lines = append(lines, fmt.Sprintf("  %s: %s;", name, tsType))
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "export interface %s {\n", capitalize(s.Name))
+	b.WriteString(strings.Join(lines, "\n"))
+	b.WriteString("\n}\n")
+	return b.String(), nil
+}
+
+// tsFieldType returns f's TypeScript type: an element-typed array for
+// DataTypeArray, a union of string literals for DataTypeEnum, or the
+// registered scalar otherwise.
+func tsFieldType(f TableField) (string, error) {
+	if f.DataType == DataTypeArray {
+		elem, err := tsScalarType(f.ElementType)
+		if err != nil {
+			return "", err
+		}
+		return elem + "[]", nil
+	}
+	if f.DataType == DataTypeEnum {
+		values, err := resolveEnumValues(f)
+		if err != nil {
+			return "", err
+		}
+		if len(values) == 0 {
+			return "string", nil
+		}
+		literals := make([]string, len(values))
+		for i, v := range values {
+			literals[i] = fmt.Sprintf("%q", v)
+		}
+		return strings.Join(literals, " | "), nil
+	}
+	return tsScalarType(f.DataType)
+}
+
+func tsScalarType(dt DataType) (string, error) {
+	name, ok := tsTypeNames[dt]
+	if !ok {
+		return "", fmt.Errorf("no typescript mapping for DataType %q", dt)
+	}
+	return name, nil
+}
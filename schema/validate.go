@@ -0,0 +1,567 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var identifierRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// reservedWords are SQL keywords that make for a table or field name that
+// looks fine in TOML but breaks DDL generation.
+var reservedWords = map[string]bool{
+	"select": true, "insert": true, "update": true, "delete": true,
+	"table": true, "column": true, "order": true, "group": true,
+	"where": true, "from": true, "join": true, "index": true,
+	"primary": true, "foreign": true, "key": true, "references": true,
+	"unique": true, "default": true, "check": true, "constraint": true,
+	"null": true, "true": true, "false": true, "and": true, "or": true,
+	"not": true, "as": true, "into": true, "values": true, "create": true,
+	"drop": true, "alter": true, "grant": true, "revoke": true, "user": true,
+}
+
+// ValidateIdentifier rejects table/field names that are empty, exceed
+// Postgres's 63-byte identifier limit, don't match a leading-letter-or-
+// underscore charset, or collide with a SQL reserved word.
+func ValidateIdentifier(name string) error {
+	if name == "" {
+		return fmt.Errorf("identifier cannot be empty")
+	}
+	if len(name) > 63 {
+		return fmt.Errorf("identifier %q exceeds 63 bytes", name)
+	}
+	if !identifierRe.MatchString(name) {
+		return fmt.Errorf("identifier %q must match %s", name, identifierRe.String())
+	}
+	if reservedWords[strings.ToLower(name)] {
+		return fmt.Errorf("identifier %q is a reserved word", name)
+	}
+	return nil
+}
+
+// Validate checks that s is internally consistent: every field is
+// individually well-formed and its declared primary key, explicit or
+// implicit, names real, required fields. All problems found are joined
+// into a single error rather than stopping at the first.
+func Validate(s TableSchema) error {
+	var errs []error
+
+	if s.Name == "" {
+		errs = append(errs, fmt.Errorf("table: name is required"))
+	} else if err := ValidateIdentifier(s.Name); err != nil {
+		errs = append(errs, fmt.Errorf("table: %w", err))
+	}
+	if s.Namespace != "" {
+		if err := ValidateIdentifier(s.Namespace); err != nil {
+			errs = append(errs, fmt.Errorf("table %q: namespace: %w", s.Name, err))
+		}
+	}
+	if len(s.Fields) == 0 {
+		errs = append(errs, fmt.Errorf("table %q: at least one field is required", s.Name))
+	}
+	if s.Kind != "" && !tableKinds[s.Kind] {
+		errs = append(errs, fmt.Errorf("table %q: unsupported kind %q, expected %q or %q", s.Name, s.Kind, TableKindTable, TableKindView))
+	}
+	if s.IsView() {
+		if s.Query == "" {
+			errs = append(errs, fmt.Errorf("table %q: view requires a query", s.Name))
+		}
+	} else if s.Query != "" {
+		errs = append(errs, fmt.Errorf("table %q: query only applies to a view", s.Name))
+	}
+	if s.IsView() {
+		if s.Partition != nil {
+			errs = append(errs, fmt.Errorf("table %q: view cannot be partitioned, views don't enforce constraints", s.Name))
+		}
+		if len(s.Indexes) > 0 {
+			errs = append(errs, fmt.Errorf("table %q: view cannot declare indexes, views don't enforce constraints", s.Name))
+		}
+		if len(s.Seeds) > 0 {
+			errs = append(errs, fmt.Errorf("table %q: view cannot declare seeds, a view has no rows of its own to insert", s.Name))
+		}
+		if len(s.PrimaryKey) > 0 {
+			errs = append(errs, fmt.Errorf("table %q: view cannot declare a primary key, views don't enforce constraints", s.Name))
+		}
+		if len(s.Unique) > 0 {
+			errs = append(errs, fmt.Errorf("table %q: view cannot declare unique groups, views don't enforce constraints", s.Name))
+		}
+		if len(s.Checks) > 0 {
+			errs = append(errs, fmt.Errorf("table %q: view cannot declare check constraints, views don't enforce constraints", s.Name))
+		}
+	}
+
+	seenFieldIds := map[string]string{}
+	for name, f := range s.Fields {
+		if err := ValidateIdentifier(name); err != nil {
+			errs = append(errs, fmt.Errorf("table %q: %w", s.Name, err))
+		}
+		if err := ValidateField(name, f); err != nil {
+			errs = append(errs, err)
+		}
+		if s.IsView() {
+			if f.Unique {
+				errs = append(errs, fmt.Errorf("table %q: view field %q cannot be unique, views don't enforce constraints", s.Name, name))
+			}
+			if f.References != nil {
+				errs = append(errs, fmt.Errorf("table %q: view field %q cannot declare references, views don't enforce constraints", s.Name, name))
+			}
+			if f.Default != "" {
+				errs = append(errs, fmt.Errorf("table %q: view field %q cannot have a default, views don't enforce constraints", s.Name, name))
+			}
+		}
+		if other, ok := seenFieldIds[f.Id.String()]; ok {
+			errs = append(errs, fmt.Errorf("table %q: fields %q and %q share id %s", s.Name, other, name, f.Id))
+		} else {
+			seenFieldIds[f.Id.String()] = name
+		}
+	}
+
+	pk := s.PrimaryKey
+	if len(pk) == 0 {
+		pk = []string{"id"}
+	}
+	if !s.IsView() {
+		for _, name := range pk {
+			f, ok := s.Fields[name]
+			if !ok {
+				errs = append(errs, fmt.Errorf("table %q: primary key field %q does not exist", s.Name, name))
+				continue
+			}
+			if !f.Required {
+				errs = append(errs, fmt.Errorf("table %q: primary key field %q must be required", s.Name, name))
+			}
+		}
+	}
+	for name, f := range s.Fields {
+		if f.AutoIncrement && !(len(pk) == 1 && pk[0] == name) {
+			errs = append(errs, fmt.Errorf("table %q: auto_increment field %q must be the sole primary key field", s.Name, name))
+		}
+		if f.Encrypted {
+			if f.Unique {
+				errs = append(errs, fmt.Errorf("table %q: encrypted field %q cannot also be unique, encryption breaks equality lookups", s.Name, name))
+			}
+			for _, pkName := range pk {
+				if pkName == name {
+					errs = append(errs, fmt.Errorf("table %q: encrypted field %q cannot be part of the primary key, encryption breaks lookups", s.Name, name))
+				}
+			}
+		}
+	}
+
+	for _, group := range s.Unique {
+		if len(group) == 0 {
+			errs = append(errs, fmt.Errorf("table %q: unique constraint lists no fields", s.Name))
+			continue
+		}
+		for _, fieldName := range group {
+			f, ok := s.Fields[fieldName]
+			if !ok {
+				errs = append(errs, fmt.Errorf("table %q: unique constraint references unknown field %q", s.Name, fieldName))
+				continue
+			}
+			if f.Encrypted {
+				errs = append(errs, fmt.Errorf("table %q: encrypted field %q cannot be part of a unique constraint, encryption breaks equality lookups", s.Name, fieldName))
+			}
+		}
+		if len(group) == 1 && s.Fields[group[0]].Unique {
+			errs = append(errs, fmt.Errorf("table %q: unique constraint on %q duplicates the field's own unique", s.Name, group[0]))
+		}
+	}
+
+	for i, fk := range s.ForeignKeys {
+		if len(fk.Columns) == 0 {
+			errs = append(errs, fmt.Errorf("table %q: foreign key %d lists no columns", s.Name, i))
+			continue
+		}
+		if len(fk.Columns) != len(fk.RefColumns) {
+			errs = append(errs, fmt.Errorf("table %q: foreign key %d has %d column(s) but %d ref_column(s)", s.Name, i, len(fk.Columns), len(fk.RefColumns)))
+		}
+		for _, fieldName := range fk.Columns {
+			if _, ok := s.Fields[fieldName]; !ok {
+				errs = append(errs, fmt.Errorf("table %q: foreign key %d references unknown field %q", s.Name, i, fieldName))
+			}
+		}
+		if fk.Namespace != "" {
+			if err := ValidateIdentifier(fk.Namespace); err != nil {
+				errs = append(errs, fmt.Errorf("table %q: foreign key %d: namespace: %w", s.Name, i, err))
+			}
+		}
+		if !referentialActions[fk.OnDelete] {
+			errs = append(errs, fmt.Errorf("table %q: foreign key %d: unsupported on_delete %q, expected cascade, restrict, \"set null\", or \"no action\"", s.Name, i, fk.OnDelete))
+		}
+		if !referentialActions[fk.OnUpdate] {
+			errs = append(errs, fmt.Errorf("table %q: foreign key %d: unsupported on_update %q, expected cascade, restrict, \"set null\", or \"no action\"", s.Name, i, fk.OnUpdate))
+		}
+	}
+
+	seenCheckNames := map[string]bool{}
+	for _, check := range s.Checks {
+		if check.Name == "" {
+			errs = append(errs, fmt.Errorf("table %q: check constraint requires a name", s.Name))
+		} else if seenCheckNames[check.Name] {
+			errs = append(errs, fmt.Errorf("table %q: duplicate check constraint name %q", s.Name, check.Name))
+		}
+		seenCheckNames[check.Name] = true
+		if check.Expression == "" {
+			errs = append(errs, fmt.Errorf("table %q: check constraint %q requires an expression", s.Name, check.Name))
+		}
+	}
+
+	for _, clause := range s.OrderBy {
+		if _, ok := s.Fields[clause.Field]; !ok {
+			errs = append(errs, fmt.Errorf("table %q: order_by references unknown field %q", s.Name, clause.Field))
+		}
+	}
+
+	if p := s.Partition; p != nil {
+		if !partitionStrategies[p.Strategy] {
+			errs = append(errs, fmt.Errorf("table %q: partition: unsupported strategy %q, expected range, list, or hash", s.Name, p.Strategy))
+		}
+		if len(p.Fields) == 0 {
+			errs = append(errs, fmt.Errorf("table %q: partition: requires at least one field", s.Name))
+		}
+		for _, name := range p.Fields {
+			f, ok := s.Fields[name]
+			if !ok {
+				errs = append(errs, fmt.Errorf("table %q: partition references unknown field %q", s.Name, name))
+				continue
+			}
+			if p.Strategy == PartitionRange || p.Strategy == PartitionList {
+				if f.DataType == DataTypeJSON || f.DataType == DataTypeArray || f.DataType == DataTypeBytes {
+					errs = append(errs, fmt.Errorf("table %q: partition field %q of type %s doesn't support %s partitioning", s.Name, name, f.DataType, p.Strategy))
+				}
+			}
+		}
+	}
+
+	seenIndexNames := map[string]bool{}
+	for _, idx := range s.Indexes {
+		if seenIndexNames[idx.Name] {
+			errs = append(errs, fmt.Errorf("table %q: duplicate index name %q", s.Name, idx.Name))
+		}
+		seenIndexNames[idx.Name] = true
+		if !indexMethods[idx.Method] {
+			errs = append(errs, fmt.Errorf("table %q: index %q: unsupported method %q, expected btree, hash, gin, or gist", s.Name, idx.Name, idx.Method))
+		}
+		for _, fieldName := range idx.Fields {
+			f, ok := s.Fields[fieldName]
+			if !ok {
+				errs = append(errs, fmt.Errorf("table %q: index %q references unknown field %q", s.Name, idx.Name, fieldName))
+				continue
+			}
+			if idx.Method == "gin" && f.DataType != DataTypeJSON && f.DataType != DataTypeArray {
+				errs = append(errs, fmt.Errorf("table %q: index %q: gin requires json or array columns, field %q is %s", s.Name, idx.Name, fieldName, f.DataType))
+			}
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %w", ErrValidation, errors.Join(errs...))
+}
+
+// ValidateField checks that a field's type-specific attributes are
+// well-formed, independent of its place in a table.
+func ValidateField(name string, f TableField) error {
+	if !f.DataType.Valid() {
+		_, err := ParseDataType(string(f.DataType))
+		return fmt.Errorf("field %q: %w", name, err)
+	}
+	if spec := dataTypeRegistry[string(f.DataType)]; spec.Validate != nil {
+		if err := spec.Validate(name, f); err != nil {
+			return err
+		}
+	}
+	if f.Pattern != "" {
+		if f.DataType != DataTypeString {
+			return fmt.Errorf("field %q: pattern only applies to string fields, got %s", name, f.DataType)
+		}
+		if _, err := regexp.Compile(f.Pattern); err != nil {
+			return fmt.Errorf("field %q: invalid pattern %q: %w", name, f.Pattern, err)
+		}
+	}
+	if f.Collation != "" {
+		if f.DataType != DataTypeString {
+			return fmt.Errorf("field %q: collation only applies to string fields, got %s", name, f.DataType)
+		}
+	}
+	if _, ok := sensitivityLevels[f.Sensitivity]; !ok {
+		return fmt.Errorf("field %q: unsupported sensitivity %q, expected public, internal, confidential, or pii", name, f.Sensitivity)
+	}
+	if f.MaxBytes != 0 {
+		if f.DataType != DataTypeBytes {
+			return fmt.Errorf("field %q: max_bytes only applies to bytes fields, got %s", name, f.DataType)
+		}
+		if f.MaxBytes < 0 {
+			return fmt.Errorf("field %q: max_bytes cannot be negative", name)
+		}
+	}
+	if f.MaxItems != 0 {
+		if f.DataType != DataTypeArray {
+			return fmt.Errorf("field %q: max_items only applies to array fields, got %s", name, f.DataType)
+		}
+		if f.MaxItems < 0 {
+			return fmt.Errorf("field %q: max_items cannot be negative", name)
+		}
+	}
+	if f.UUIDVersion != 0 {
+		if f.DataType != DataTypeUUID {
+			return fmt.Errorf("field %q: uuid_version only applies to uuid fields, got %s", name, f.DataType)
+		}
+		if f.UUIDVersion != 4 && f.UUIDVersion != 7 {
+			return fmt.Errorf("field %q: unsupported uuid_version %d, expected 4 or 7", name, f.UUIDVersion)
+		}
+	}
+	if f.TimePrecision != 0 {
+		if !f.DataType.IsTemporal() || f.DataType == DataTypeDate {
+			return fmt.Errorf("field %q: time_precision only applies to timestamp/time fields, got %s", name, f.DataType)
+		}
+		if f.TimePrecision < 0 || f.TimePrecision > 6 {
+			return fmt.Errorf("field %q: time_precision must be between 0 and 6, got %d", name, f.TimePrecision)
+		}
+	}
+	if f.IsGenerated() {
+		if f.Default != "" {
+			return fmt.Errorf("field %q: generated fields cannot also have a default", name)
+		}
+		if f.Automatic {
+			return fmt.Errorf("field %q: generated fields cannot also be automatic", name)
+		}
+	} else if f.Stored {
+		return fmt.Errorf("field %q: stored only applies to generated fields", name)
+	}
+	if f.Automatic && !f.AutoIncrement && f.DataType != DataTypeUUID && !f.DataType.IsTemporal() && !f.DefaultExpr {
+		return fmt.Errorf("field %q: automatic field of type %s must be uuid or temporal (the only types with a built-in generator), or set default_expr to supply one", name, f.DataType)
+	}
+	if err := f.ValidateDefault(); err != nil {
+		return fmt.Errorf("field %q: %w", name, err)
+	}
+	if err := f.ValidateRange(); err != nil {
+		return fmt.Errorf("field %q: %w", name, err)
+	}
+	if f.UniqueNullsNotDistinct {
+		if !f.Unique {
+			return fmt.Errorf("field %q: unique_nulls_not_distinct requires unique to also be set", name)
+		}
+		if !f.Nullable() {
+			return fmt.Errorf("field %q: unique_nulls_not_distinct only applies to a nullable field, there's no NULL handling to disambiguate on one that's required or automatic", name)
+		}
+	}
+	if f.AutoIncrement {
+		if f.DataType != DataTypeInt32 && f.DataType != DataTypeInt64 {
+			return fmt.Errorf("field %q: auto_increment only applies to int32/int64 fields, got %s", name, f.DataType)
+		}
+		if !f.Automatic || !f.Required {
+			return fmt.Errorf("field %q: auto_increment requires automatic and required to both be true", name)
+		}
+		if f.Default != "" {
+			return fmt.Errorf("field %q: auto_increment fields cannot also have a default", name)
+		}
+		if f.IsGenerated() {
+			return fmt.Errorf("field %q: auto_increment fields cannot also be generated", name)
+		}
+	}
+	return nil
+}
+
+// ValidateRange checks that f.Min and f.Max, when set, only apply to a
+// numeric field and that Min does not exceed Max.
+func (f TableField) ValidateRange() error {
+	if f.Min == nil && f.Max == nil {
+		return nil
+	}
+	if !f.DataType.IsNumeric() {
+		return fmt.Errorf("min/max only apply to numeric fields, got %s", f.DataType)
+	}
+	if f.Min != nil && f.Max != nil && *f.Min > *f.Max {
+		return fmt.Errorf("min %v cannot exceed max %v", *f.Min, *f.Max)
+	}
+	return nil
+}
+
+// ValidateReferences checks that f's References points at a field that
+// actually exists in known and that its DataType matches f's, so a string
+// FK can't silently point at a uuid primary key.
+func ValidateReferences(name string, f TableField, known map[string]TableSchema) error {
+	if f.References == nil {
+		return nil
+	}
+	if f.References.Namespace != "" {
+		if err := ValidateIdentifier(f.References.Namespace); err != nil {
+			return fmt.Errorf("field %q: references namespace: %w", name, err)
+		}
+	}
+	target, ok := known[f.References.Table]
+	if !ok {
+		return fmt.Errorf("field %q: references unknown table %q", name, f.References.Table)
+	}
+	if target.IsView() {
+		return fmt.Errorf("field %q: references %q, which is a view, foreign keys cannot target a view", name, f.References.Table)
+	}
+	targetField, ok := target.Fields[f.References.Field]
+	if !ok {
+		return fmt.Errorf("field %q: references unknown field %q on table %q", name, f.References.Field, f.References.Table)
+	}
+	if targetField.DataType != f.DataType {
+		return fmt.Errorf("field %q: type %s does not match referenced field %s.%s of type %s", name, f.DataType, f.References.Table, f.References.Field, targetField.DataType)
+	}
+	if !referentialActions[f.References.OnDelete] {
+		return fmt.Errorf("field %q: unsupported on_delete %q, expected cascade, restrict, \"set null\", or \"no action\"", name, f.References.OnDelete)
+	}
+	if !referentialActions[f.References.OnUpdate] {
+		return fmt.Errorf("field %q: unsupported on_update %q, expected cascade, restrict, \"set null\", or \"no action\"", name, f.References.OnUpdate)
+	}
+	if f.Required && (f.References.OnDelete == "set null" || f.References.OnUpdate == "set null") {
+		return fmt.Errorf("field %q: on_delete/on_update \"set null\" contradicts required", name)
+	}
+	return nil
+}
+
+// ValidateForeignKeys checks that every TableSchema.ForeignKeys entry on s
+// targets a real table, that its columns line up pairwise by type with the
+// target's, and that the referenced columns actually form a key on the
+// target — its primary key or one of its unique groups — since Postgres
+// rejects a REFERENCES clause that doesn't point at one.
+func ValidateForeignKeys(s TableSchema, known map[string]TableSchema) error {
+	var errs []error
+	for i, fk := range s.ForeignKeys {
+		target, ok := known[fk.RefTable]
+		if !ok {
+			errs = append(errs, fmt.Errorf("table %q: foreign key %d references unknown table %q", s.Name, i, fk.RefTable))
+			continue
+		}
+		if target.IsView() {
+			errs = append(errs, fmt.Errorf("table %q: foreign key %d references %q, which is a view, foreign keys cannot target a view", s.Name, i, fk.RefTable))
+			continue
+		}
+		if len(fk.Columns) != len(fk.RefColumns) {
+			continue // already reported by Validate
+		}
+		ok = true
+		for j, refName := range fk.RefColumns {
+			refField, exists := target.Fields[refName]
+			if !exists {
+				errs = append(errs, fmt.Errorf("table %q: foreign key %d references unknown field %q on table %q", s.Name, i, refName, fk.RefTable))
+				ok = false
+				continue
+			}
+			colName := fk.Columns[j]
+			col, exists := s.Fields[colName]
+			if !exists {
+				continue // already reported by Validate
+			}
+			if col.DataType != refField.DataType {
+				errs = append(errs, fmt.Errorf("table %q: foreign key %d: column %q of type %s does not match referenced field %s.%s of type %s", s.Name, i, colName, col.DataType, fk.RefTable, refName, refField.DataType))
+				ok = false
+			}
+		}
+		if ok && !tableHasKey(target, fk.RefColumns) {
+			errs = append(errs, fmt.Errorf("table %q: foreign key %d: columns %v do not form a key (primary key or unique constraint) on table %q", s.Name, i, fk.RefColumns, fk.RefTable))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// tableHasKey reports whether columns, as a set, match s's primary key or
+// one of its unique groups.
+func tableHasKey(s TableSchema, columns []string) bool {
+	pk := s.PrimaryKey
+	if len(pk) == 0 {
+		pk = []string{"id"}
+	}
+	if sameFieldSet(pk, columns) {
+		return true
+	}
+	for _, group := range s.Unique {
+		if sameFieldSet(group, columns) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameFieldSet reports whether a and b name the same fields, ignoring
+// order but not duplicates: ["x", "x"] is not the same set as ["x", "y"]
+// even though both contain "x".
+func sameFieldSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string{}, a...)
+	sortedB := append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateRelationships checks that every Relationship on s names a real
+// target table, and that a many-to-many relationship's Through names a
+// real join table.
+func ValidateRelationships(s TableSchema, known map[string]TableSchema) error {
+	var errs []error
+	for _, rel := range s.Relationships {
+		if _, ok := known[rel.Target]; !ok {
+			errs = append(errs, fmt.Errorf("table %q: relationship %q targets unknown table %q", s.Name, rel.Name, rel.Target))
+		}
+		if rel.Kind == RelationshipManyToMany {
+			if rel.Through == "" {
+				errs = append(errs, fmt.Errorf("table %q: many-to-many relationship %q requires a through-table", s.Name, rel.Name))
+			} else if _, ok := known[rel.Through]; !ok {
+				errs = append(errs, fmt.Errorf("table %q: relationship %q's through-table %q does not exist", s.Name, rel.Name, rel.Through))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateDefault checks that f.Default, if set, is a literal that parses
+// against f.DataType. Automatic fields (e.g. the generated id/timestamps)
+// manage their own value and may not also declare a default. When
+// DefaultExpr is set, Default is a raw expression like "now()" rather
+// than a literal, so it's exempt from the type check.
+func (f TableField) ValidateDefault() error {
+	if f.Default == "" {
+		if f.DefaultExpr {
+			return fmt.Errorf("default_expr requires a default expression")
+		}
+		return nil
+	}
+	if f.DefaultExpr {
+		return nil
+	}
+	if f.Automatic {
+		return fmt.Errorf("automatic fields cannot also have a literal default")
+	}
+	switch f.DataType {
+	case DataTypeBool:
+		if _, err := strconv.ParseBool(f.Default); err != nil {
+			return fmt.Errorf("default %q is not a valid bool", f.Default)
+		}
+	case DataTypeInt32, DataTypeInt64:
+		if _, err := strconv.ParseInt(f.Default, 10, 64); err != nil {
+			return fmt.Errorf("default %q is not a valid integer", f.Default)
+		}
+	case DataTypeFloat32, DataTypeFloat64:
+		if _, err := strconv.ParseFloat(f.Default, 64); err != nil {
+			return fmt.Errorf("default %q is not a valid float", f.Default)
+		}
+	case DataTypeEnum:
+		if !f.IsValidValue(f.Default) {
+			values, err := resolveEnumValues(f)
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("default %q is not one of the enum's allowed values %v", f.Default, values)
+		}
+	}
+	return nil
+}